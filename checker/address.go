@@ -0,0 +1,140 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// IPFamily restricts the IP addresses that a host is resolved to.
+type IPFamily int
+
+const (
+	// IPFamilyAny allows both IPv4 and IPv6 addresses
+	IPFamilyAny IPFamily = 0
+	// IPFamilyIPv4 allows only IPv4 addresses
+	IPFamilyIPv4 IPFamily = 4
+	// IPFamilyIPv6 allows only IPv6 addresses
+	IPFamilyIPv6 IPFamily = 6
+)
+
+// ErrInvalidIPFamily defines invalid IP family error
+var ErrInvalidIPFamily = errors.New("invalid IP family, expected 4 or 6")
+
+// ParseIPFamily returns the IP family of the given number, 0 means any family.
+func ParseIPFamily(family int) (IPFamily, error) {
+	switch f := IPFamily(family); f {
+	case IPFamilyAny, IPFamilyIPv4, IPFamilyIPv6:
+		return f, nil
+	default:
+		return IPFamilyAny, ErrInvalidIPFamily
+	}
+}
+
+// Network restricts the given network to the IP family, e.g. "tcp" becomes "tcp4" for IPv4.
+func (f IPFamily) Network(network string) string {
+	switch f {
+	case IPFamilyIPv4:
+		return network + "4"
+	case IPFamilyIPv6:
+		return network + "6"
+	default:
+		return network
+	}
+}
+
+// LookupAddresses resolves the host of the given "host:port" address, and returns
+// an address for each of its IPs in the IP family.
+func LookupAddresses(ctx context.Context, resolver *net.Resolver, address string, family IPFamily) ([]string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	ips, err := resolver.LookupIP(ctx, family.Network("ip"), host)
+	if err != nil {
+		return nil, NewExpectedError("failed to resolve the host", err, "host", host)
+	}
+
+	addresses := make([]string, len(ips))
+	for i, ip := range ips {
+		addresses[i] = net.JoinHostPort(ip.String(), port)
+	}
+
+	return addresses, nil
+}
+
+// CheckAll runs the check against each of the addresses concurrently. It returns nil
+// when all of them pass, otherwise an ExpectedError that reports the ready and the failed addresses.
+func CheckAll(ctx context.Context, addresses []string, check func(ctx context.Context, address string) error) error {
+	ready, failed, cause := checkEach(ctx, addresses, check)
+	if len(failed) == 0 {
+		return nil
+	}
+
+	return NewExpectedError(
+		fmt.Sprintf("%d of %d addresses aren't ready", len(failed), len(addresses)), cause,
+		"ready", ready, "failed", failed,
+	)
+}
+
+// CheckAtLeast runs the check against each of the addresses concurrently. It returns nil
+// when at least the required number of them pass, otherwise an ExpectedError that reports
+// the ready and the failed addresses.
+func CheckAtLeast(ctx context.Context, addresses []string, required int, check func(ctx context.Context, address string) error) error {
+	ready, failed, cause := checkEach(ctx, addresses, check)
+	if len(ready) >= required {
+		return nil
+	}
+
+	return NewExpectedError(
+		fmt.Sprintf("%d of %d addresses are ready, expected at least %d", len(ready), len(addresses), required), cause,
+		"ready", ready, "failed", failed,
+	)
+}
+
+// checkEach runs the check against each of the addresses concurrently, and returns
+// the ready and the failed addresses along with the joined errors of the failed ones.
+func checkEach(ctx context.Context, addresses []string, check func(ctx context.Context, address string) error) (ready, failed []string, cause error) {
+	errs := make([]error, len(addresses))
+
+	var wg sync.WaitGroup
+	for i, address := range addresses {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			errs[i] = check(ctx, address)
+		}()
+	}
+
+	wg.Wait()
+
+	var causes []error
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, addresses[i])
+			causes = append(causes, fmt.Errorf("%s: %w", addresses[i], err))
+		} else {
+			ready = append(ready, addresses[i])
+		}
+	}
+
+	return ready, failed, errors.Join(causes...)
+}
@@ -0,0 +1,109 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseIPFamily tests parsing the IP families
+func TestParseIPFamily(t *testing.T) {
+	for _, family := range []int{0, 4, 6} {
+		f, err := ParseIPFamily(family)
+		assert.NoError(t, err)
+		assert.Equal(t, IPFamily(family), f)
+	}
+
+	_, err := ParseIPFamily(5)
+	assert.ErrorIs(t, err, ErrInvalidIPFamily)
+}
+
+// TestIPFamilyNetwork tests restricting a network to an IP family
+func TestIPFamilyNetwork(t *testing.T) {
+	assert.Equal(t, "tcp", IPFamilyAny.Network("tcp"))
+	assert.Equal(t, "tcp4", IPFamilyIPv4.Network("tcp"))
+	assert.Equal(t, "ip6", IPFamilyIPv6.Network("ip"))
+}
+
+// TestLookupAddresses tests resolving the addresses of a host
+func TestLookupAddresses(t *testing.T) {
+	addresses, err := LookupAddresses(context.Background(), net.DefaultResolver, "127.0.0.1:80", IPFamilyAny)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"127.0.0.1:80"}, addresses)
+
+	addresses, err = LookupAddresses(context.Background(), net.DefaultResolver, "[::1]:80", IPFamilyIPv6)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"[::1]:80"}, addresses)
+
+	_, err = LookupAddresses(context.Background(), net.DefaultResolver, "127.0.0.1", IPFamilyAny)
+	assert.Error(t, err)
+}
+
+// TestCheckAll tests checking several addresses
+func TestCheckAll(t *testing.T) {
+	addresses := []string{"10.0.0.1:80", "10.0.0.2:80", "10.0.0.3:80"}
+
+	err := CheckAll(context.Background(), addresses, func(context.Context, string) error {
+		return nil
+	})
+	assert.NoError(t, err)
+
+	refused := errors.New("connection refused")
+	err = CheckAll(context.Background(), addresses, func(_ context.Context, address string) error {
+		if address == "10.0.0.2:80" {
+			return refused
+		}
+		return nil
+	})
+
+	var expectedError *ExpectedError
+	if assert.ErrorAs(t, err, &expectedError) {
+		assert.ErrorIs(t, err, refused)
+		assert.Contains(t, err.Error(), "1 of 3 addresses aren't ready")
+		assert.Contains(t, err.Error(), "10.0.0.2:80: connection refused")
+		assert.Equal(t, []any{
+			"ready", []string{"10.0.0.1:80", "10.0.0.3:80"},
+			"failed", []string{"10.0.0.2:80"},
+		}, expectedError.Details())
+	}
+}
+
+// TestCheckAtLeast tests checking a minimum number of addresses
+func TestCheckAtLeast(t *testing.T) {
+	addresses := []string{"10.0.0.1:80", "10.0.0.2:80", "10.0.0.3:80"}
+	check := func(_ context.Context, address string) error {
+		if address == "10.0.0.1:80" {
+			return nil
+		}
+		return errors.New("connection refused")
+	}
+
+	assert.NoError(t, CheckAtLeast(context.Background(), addresses, 1, check))
+
+	var expectedError *ExpectedError
+	err := CheckAtLeast(context.Background(), addresses, 2, check)
+	if assert.ErrorAs(t, err, &expectedError) {
+		assert.Contains(t, err.Error(), "1 of 3 addresses are ready, expected at least 2")
+		assert.Equal(t, []any{
+			"ready", []string{"10.0.0.1:80"},
+			"failed", []string{"10.0.0.2:80", "10.0.0.3:80"},
+		}, expectedError.Details())
+	}
+}
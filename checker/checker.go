@@ -17,6 +17,7 @@ package checker
 
 import (
 	"context"
+	"io"
 )
 
 // Checker is the interface that wraps the basic checker methods
@@ -24,3 +25,10 @@ type Checker interface {
 	Identity() (string, error)
 	Check(ctx context.Context) error
 }
+
+// Closer is the interface of the checkers that hold resources between the checks, e.g. the clients
+// of the persistent mode. The waiter closes the checker when the wait finishes.
+type Closer interface {
+	Checker
+	io.Closer
+}
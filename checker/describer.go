@@ -0,0 +1,104 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+)
+
+// Description is the structured metadata of a checker, that the logs, reports and metrics use
+type Description struct {
+	// Kind is the kind of the checker, e.g. "TCP", "HTTP" or "PostgreSQL"
+	Kind string
+	// Host is the host of the target, or a bare target that has no port, e.g. a domain or a socket path
+	Host string
+	// Port is the port of the target, if any
+	Port string
+	// Protocol is the protocol of the target, e.g. "tcp", "https" or "amqps"
+	Protocol string
+	// Display is the redacted target to display
+	Display string
+	// Labels are the other facts of the checker, e.g. the database or the DNS record type
+	Labels map[string]string
+}
+
+// Describer is the interface of the checkers that describe themselves with a Description
+type Describer interface {
+	Describe() (Description, error)
+}
+
+// Describe returns the description of the checker, from its Describer, or else
+// from the name of its type and its identity.
+func Describe(chk Checker) (Description, error) {
+	if describer, ok := chk.(Describer); ok {
+		desc, err := describer.Describe()
+		if err != nil {
+			return Description{}, err
+		}
+
+		desc.Display = Redact(desc.Display)
+
+		return desc, nil
+	}
+
+	identity, err := chk.Identity()
+	if err != nil {
+		return Description{}, err
+	}
+
+	var kind string
+	if t := reflect.TypeOf(chk); t.Kind() == reflect.Pointer {
+		kind = t.Elem().Name()
+	} else {
+		kind = t.Name()
+	}
+
+	return DescribeAddress(kind, "", identity), nil
+}
+
+// DescribeAddress returns the description of a checker of the kind that connects to the address over the protocol.
+// The address is a "host:port" or a bare host.
+func DescribeAddress(kind, protocol, address string) Description {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		host, port = address, ""
+	}
+
+	return Description{
+		Kind:     kind,
+		Host:     host,
+		Port:     port,
+		Protocol: protocol,
+		Display:  Redact(address),
+	}
+}
+
+// DescribeURL returns the description of a checker of the kind that connects to the URL, over the protocol of its scheme
+func DescribeURL(kind, rawURL string) (Description, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return Description{}, RedactError(err)
+	}
+
+	return Description{
+		Kind:     kind,
+		Host:     u.Hostname(),
+		Port:     u.Port(),
+		Protocol: u.Scheme,
+		Display:  Redact(rawURL),
+	}, nil
+}
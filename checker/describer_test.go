@@ -0,0 +1,91 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// describerChecker is a MockChecker that describes itself.
+type describerChecker struct {
+	MockChecker
+	desc Description
+	err  error
+}
+
+// Describe returns the description of the checker
+func (dc *describerChecker) Describe() (Description, error) {
+	return dc.desc, dc.err
+}
+
+// TestDescribe tests describing a checker with its Describer
+func TestDescribe(t *testing.T) {
+	chk := &describerChecker{desc: Description{Kind: "Test", Display: "redis://:secret@cache:6379"}}
+
+	desc, err := Describe(chk)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test", desc.Kind)
+	assert.Equal(t, "redis://:***@cache:6379", desc.Display)
+
+	errDescribe := errors.New("describe error")
+	_, err = Describe(&describerChecker{err: errDescribe})
+	assert.ErrorIs(t, err, errDescribe)
+}
+
+// TestDescribeFallback tests describing a checker from the name of its type and its identity
+func TestDescribeFallback(t *testing.T) {
+	chk := new(MockChecker)
+	chk.On("Identity").Return("127.0.0.1:8080", nil).Once()
+
+	desc, err := Describe(chk)
+	assert.NoError(t, err)
+	assert.Equal(t, Description{Kind: "MockChecker", Host: "127.0.0.1", Port: "8080", Display: "127.0.0.1:8080"}, desc)
+
+	errIdentity := errors.New("identity error")
+	chk.On("Identity").Return("", errIdentity).Once()
+	_, err = Describe(chk)
+	assert.ErrorIs(t, err, errIdentity)
+	chk.AssertExpectations(t)
+}
+
+// TestDescribeAddress tests describing the host and the port of an address
+func TestDescribeAddress(t *testing.T) {
+	desc := DescribeAddress("TCP", "tcp", "[::1]:5432")
+	assert.Equal(t, Description{Kind: "TCP", Host: "::1", Port: "5432", Protocol: "tcp", Display: "[::1]:5432"}, desc)
+
+	desc = DescribeAddress("Exec", "", "/var/run/app.sock")
+	assert.Equal(t, "/var/run/app.sock", desc.Host)
+	assert.Empty(t, desc.Port)
+}
+
+// TestDescribeURL tests describing a URL
+func TestDescribeURL(t *testing.T) {
+	desc, err := DescribeURL("PostgreSQL", "postgres://bob:secret@db:5432/app")
+	assert.NoError(t, err)
+	assert.Equal(t, Description{
+		Kind:     "PostgreSQL",
+		Host:     "db",
+		Port:     "5432",
+		Protocol: "postgres",
+		Display:  "postgres://bob:***@db:5432/app",
+	}, desc)
+
+	_, err = DescribeURL("HTTP", "http://bob:secret@[::1")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret")
+}
@@ -0,0 +1,60 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dialer provides the dialer abstraction shared by the network checkers of the Wait4X application.
+package dialer
+
+import (
+	"context"
+	"net"
+	"time"
+)
+
+// DialFunc connects to the address on the named network, like net.Dialer.DialContext.
+type DialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// DialContext calls d(ctx, network, address), it makes DialFunc a context dialer of the drivers.
+func (d DialFunc) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	return d(ctx, network, address)
+}
+
+// Dial calls d with a background context, it makes DialFunc a golang.org/x/net/proxy.Dialer.
+func (d DialFunc) Dial(network, address string) (net.Conn, error) {
+	return d(context.Background(), network, address)
+}
+
+// Direct returns a DialFunc that connects directly within the timeout, zero means no timeout.
+func Direct(timeout time.Duration) DialFunc {
+	d := &net.Dialer{Timeout: timeout}
+	return d.DialContext
+}
+
+// WithTimeout returns a DialFunc that bounds the dial with the timeout, zero means no timeout.
+// A nil dial falls back to Direct, so checkers can call it with their optional dialer.
+func WithTimeout(dial DialFunc, timeout time.Duration) DialFunc {
+	if dial == nil {
+		return Direct(timeout)
+	}
+
+	if timeout <= 0 {
+		return dial
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return dial(ctx, network, address)
+	}
+}
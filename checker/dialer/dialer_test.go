@@ -0,0 +1,309 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialer
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// greeting is what the target server sends first, like MySQL does
+const greeting = "hello"
+
+// DialerSuite is a test suite for dialer package
+type DialerSuite struct {
+	suite.Suite
+
+	// Shared resources for the test suite
+	target      net.Listener
+	httpProxy   net.Listener
+	socksProxy  net.Listener
+	proxyDialed atomic.Int32
+}
+
+// SetupSuite starts the target server and the proxies
+func (s *DialerSuite) SetupSuite() {
+	var err error
+
+	s.target, err = net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	go serve(s.target, func(conn net.Conn) {
+		conn.Write([]byte(greeting))
+	})
+
+	s.httpProxy, err = net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	go serve(s.httpProxy, s.serveHTTPProxy)
+
+	s.socksProxy, err = net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	go serve(s.socksProxy, s.serveSOCKS5Proxy)
+}
+
+// TearDownSuite stops the target server and the proxies
+func (s *DialerSuite) TearDownSuite() {
+	s.Require().NoError(s.target.Close())
+	s.Require().NoError(s.httpProxy.Close())
+	s.Require().NoError(s.socksProxy.Close())
+}
+
+// SetupTest resets the proxy counter
+func (s *DialerSuite) SetupTest() {
+	s.proxyDialed.Store(0)
+}
+
+// serve accepts the connections of the listener until it's closed
+func serve(l net.Listener, handle func(conn net.Conn)) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return // listener closed
+		}
+
+		go func() {
+			defer conn.Close()
+			handle(conn)
+		}()
+	}
+}
+
+// tunnel dials the address and pipes the connections together
+func (s *DialerSuite) tunnel(conn net.Conn, r io.Reader, address string, established func(ok bool)) {
+	s.proxyDialed.Add(1)
+
+	upstream, err := net.Dial("tcp", address)
+	established(err == nil)
+	if err != nil {
+		return
+	}
+	defer upstream.Close()
+
+	go io.Copy(upstream, r)
+	io.Copy(conn, upstream)
+}
+
+// serveHTTPProxy serves an HTTP CONNECT proxy that requires the user "user" and the password "pass"
+func (s *DialerSuite) serveHTTPProxy(conn net.Conn) {
+	br := bufio.NewReader(conn)
+	req, err := http.ReadRequest(br)
+	if err != nil {
+		return
+	}
+
+	if req.Method != http.MethodConnect || req.Header.Get("Proxy-Authorization") != "Basic dXNlcjpwYXNz" {
+		conn.Write([]byte("HTTP/1.1 407 Proxy Authentication Required\r\n\r\n"))
+		return
+	}
+
+	s.tunnel(conn, br, req.Host, func(ok bool) {
+		if ok {
+			conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+		} else {
+			conn.Write([]byte("HTTP/1.1 502 Bad Gateway\r\n\r\n"))
+		}
+	})
+}
+
+// serveSOCKS5Proxy serves a SOCKS5 proxy without authentication that supports the CONNECT command
+func (s *DialerSuite) serveSOCKS5Proxy(conn net.Conn) {
+	br := bufio.NewReader(conn)
+
+	// Greeting: version, number of methods and the methods.
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return
+	}
+	if _, err := io.ReadFull(br, make([]byte, header[1])); err != nil {
+		return
+	}
+	conn.Write([]byte{5, 0})
+
+	// Request: version, command, reserved, address type, address and port.
+	request := make([]byte, 4)
+	if _, err := io.ReadFull(br, request); err != nil {
+		return
+	}
+
+	var host string
+	switch request[3] {
+	case 1:
+		ip := make([]byte, net.IPv4len)
+		if _, err := io.ReadFull(br, ip); err != nil {
+			return
+		}
+		host = net.IP(ip).String()
+	case 3:
+		length, err := br.ReadByte()
+		if err != nil {
+			return
+		}
+		name := make([]byte, length)
+		if _, err := io.ReadFull(br, name); err != nil {
+			return
+		}
+		host = string(name)
+	default:
+		return
+	}
+
+	port := make([]byte, 2)
+	if _, err := io.ReadFull(br, port); err != nil {
+		return
+	}
+
+	address := net.JoinHostPort(host, strconv.Itoa(int(binary.BigEndian.Uint16(port))))
+	s.tunnel(conn, br, address, func(ok bool) {
+		reply := byte(0)
+		if !ok {
+			reply = 5 // connection refused
+		}
+		conn.Write([]byte{5, reply, 0, 1, 0, 0, 0, 0, 0, 0})
+	})
+}
+
+// readGreeting reads the greeting of the target server from the connection
+func (s *DialerSuite) readGreeting(conn net.Conn) {
+	defer conn.Close()
+
+	s.Require().NoError(conn.SetReadDeadline(time.Now().Add(time.Second)))
+	buf := make([]byte, len(greeting))
+	_, err := io.ReadFull(conn, buf)
+	s.Require().NoError(err)
+	s.Equal(greeting, string(buf))
+}
+
+// TestHTTPProxy tests connecting through an HTTP CONNECT proxy
+func (s *DialerSuite) TestHTTPProxy() {
+	proxyURL := &url.URL{Scheme: "http", User: url.UserPassword("user", "pass"), Host: s.httpProxy.Addr().String()}
+	dial, err := Proxy(proxyURL, Direct(time.Second))
+	s.Require().NoError(err)
+
+	conn, err := dial(context.Background(), "tcp", s.target.Addr().String())
+	s.Require().NoError(err)
+	s.readGreeting(conn)
+	s.Equal(int32(1), s.proxyDialed.Load())
+}
+
+// TestHTTPProxyUnauthorized tests an HTTP CONNECT proxy that refuses the credentials
+func (s *DialerSuite) TestHTTPProxyUnauthorized() {
+	proxyURL := &url.URL{Scheme: "http", Host: s.httpProxy.Addr().String()}
+	dial, err := Proxy(proxyURL, Direct(time.Second))
+	s.Require().NoError(err)
+
+	_, err = dial(context.Background(), "tcp", s.target.Addr().String())
+	s.ErrorContains(err, "407 Proxy Authentication Required")
+}
+
+// TestSOCKS5Proxy tests connecting through a SOCKS5 proxy
+func (s *DialerSuite) TestSOCKS5Proxy() {
+	proxyURL := &url.URL{Scheme: "socks5h", Host: s.socksProxy.Addr().String()}
+	dial, err := Proxy(proxyURL, Direct(time.Second))
+	s.Require().NoError(err)
+
+	_, port, err := net.SplitHostPort(s.target.Addr().String())
+	s.Require().NoError(err)
+
+	conn, err := dial(context.Background(), "tcp", net.JoinHostPort("localhost", port))
+	s.Require().NoError(err)
+	s.readGreeting(conn)
+	s.Equal(int32(1), s.proxyDialed.Load())
+}
+
+// TestUnsupportedProxy tests a proxy URL with an unsupported scheme
+func (s *DialerSuite) TestUnsupportedProxy() {
+	_, err := Proxy(&url.URL{Scheme: "ftp", Host: "127.0.0.1:21"}, Direct(time.Second))
+	s.ErrorIs(err, ErrUnsupportedProxy)
+}
+
+// TestProxyOtherNetworks tests that the networks other than TCP don't go through the proxy
+func (s *DialerSuite) TestProxyOtherNetworks() {
+	var network string
+	forward := func(_ context.Context, n, _ string) (net.Conn, error) {
+		network = n
+		return nil, net.ErrClosed
+	}
+
+	dial, err := Proxy(&url.URL{Scheme: "http", Host: s.httpProxy.Addr().String()}, forward)
+	s.Require().NoError(err)
+
+	_, err = dial(context.Background(), "unix", "/tmp/mysql.sock")
+	s.ErrorIs(err, net.ErrClosed)
+	s.Equal("unix", network)
+	s.Equal(int32(0), s.proxyDialed.Load())
+}
+
+// TestBypass tests bypassing the proxy for the NO_PROXY hosts
+func (s *DialerSuite) TestBypass() {
+	proxyURL := &url.URL{Scheme: "socks5", Host: s.socksProxy.Addr().String()}
+	proxyDial, err := Proxy(proxyURL, Direct(time.Second))
+	s.Require().NoError(err)
+
+	dial := Bypass(proxyDial, Direct(time.Second), "127.0.0.1")
+	conn, err := dial(context.Background(), "tcp", s.target.Addr().String())
+	s.Require().NoError(err)
+	s.readGreeting(conn)
+	s.Equal(int32(0), s.proxyDialed.Load())
+}
+
+// TestWithTimeout tests bounding a dial with a timeout
+func (s *DialerSuite) TestWithTimeout() {
+	blocking := func(ctx context.Context, _, _ string) (net.Conn, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	_, err := WithTimeout(blocking, 10*time.Millisecond)(context.Background(), "tcp", "127.0.0.1:0")
+	s.ErrorIs(err, context.DeadlineExceeded)
+
+	conn, err := WithTimeout(nil, time.Second)(context.Background(), "tcp", s.target.Addr().String())
+	s.Require().NoError(err)
+	s.readGreeting(conn)
+}
+
+// TestProxyFromEnvironment tests reading the proxy from the environment variables
+func (s *DialerSuite) TestProxyFromEnvironment() {
+	s.T().Setenv("ALL_PROXY", "")
+	s.T().Setenv("all_proxy", "")
+	s.T().Setenv("NO_PROXY", "")
+	s.T().Setenv("no_proxy", "")
+
+	proxyURL, _, err := ProxyFromEnvironment()
+	s.NoError(err)
+	s.Nil(proxyURL)
+
+	s.T().Setenv("all_proxy", "socks5://bastion:1080")
+	s.T().Setenv("NO_PROXY", "localhost")
+
+	proxyURL, noProxy, err := ProxyFromEnvironment()
+	s.NoError(err)
+	s.Equal("socks5://bastion:1080", proxyURL.String())
+	s.Equal("localhost", noProxy)
+}
+
+// TestDialerSuite runs the test suite
+func TestDialerSuite(t *testing.T) {
+	suite.Run(t, new(DialerSuite))
+}
@@ -0,0 +1,208 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialer
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"golang.org/x/net/proxy"
+)
+
+// ErrUnsupportedProxy defines the error of a proxy URL with an unsupported scheme
+var ErrUnsupportedProxy = errors.New("unsupported proxy scheme, expected socks5, socks5h, http or https")
+
+// Proxy returns a DialFunc that connects through the proxy of the URL, and reaches the proxy
+// itself with forward. The supported schemes are socks5 and socks5h, where the proxy resolves
+// the host names, and http and https, which use the CONNECT method. The user info of the URL
+// authenticates to the proxy. The proxies only carry TCP, so the other networks, e.g. unix
+// sockets, are dialed with forward.
+func Proxy(proxyURL *url.URL, forward DialFunc) (DialFunc, error) {
+	var dial DialFunc
+
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		var auth *proxy.Auth
+		if proxyURL.User != nil {
+			password, _ := proxyURL.User.Password()
+			auth = &proxy.Auth{User: proxyURL.User.Username(), Password: password}
+		}
+
+		d, err := proxy.SOCKS5("tcp", canonicalAddr(proxyURL), auth, forward)
+		if err != nil {
+			return nil, err
+		}
+
+		dial = d.(proxy.ContextDialer).DialContext
+	case "http", "https":
+		dial = func(ctx context.Context, _, address string) (net.Conn, error) {
+			return connect(ctx, proxyURL, forward, address)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedProxy, proxyURL.Scheme)
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		switch network {
+		case "tcp", "tcp4", "tcp6":
+			return dial(ctx, network, address)
+		default:
+			return forward(ctx, network, address)
+		}
+	}, nil
+}
+
+// Bypass returns a DialFunc that connects to the hosts of the NO_PROXY-style list, e.g.
+// "localhost,10.0.0.0/8,.internal", with direct and to the others with dial.
+func Bypass(dial, direct DialFunc, noProxy string) DialFunc {
+	if noProxy == "" {
+		return dial
+	}
+
+	perHost := proxy.NewPerHost(dial, direct)
+	perHost.AddFromString(noProxy)
+
+	return perHost.DialContext
+}
+
+// ProxyFromEnvironment returns the proxy URL of the ALL_PROXY (or all_proxy) environment
+// variable, and the NO_PROXY (or no_proxy) hosts that bypass it. The URL is nil when
+// ALL_PROXY isn't set.
+func ProxyFromEnvironment() (proxyURL *url.URL, noProxy string, err error) {
+	noProxy = getEnvAny("NO_PROXY", "no_proxy")
+
+	rawURL := getEnvAny("ALL_PROXY", "all_proxy")
+	if rawURL == "" {
+		return nil, noProxy, nil
+	}
+
+	proxyURL, err = url.Parse(rawURL)
+	if err != nil {
+		return nil, "", fmt.Errorf("can't parse the ALL_PROXY environment variable: %w", err)
+	}
+
+	return proxyURL, noProxy, nil
+}
+
+// connect opens a tunnel to the address through the HTTP proxy by the CONNECT method
+func connect(ctx context.Context, proxyURL *url.URL, forward DialFunc, address string) (net.Conn, error) {
+	conn, err := forward(ctx, "tcp", canonicalAddr(proxyURL))
+	if err != nil {
+		return nil, err
+	}
+
+	if proxyURL.Scheme == "https" {
+		conn = tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+	}
+
+	// Bound the handshake with the context, as the dial itself does.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("the proxy refused to connect to %s: %s", address, resp.Status)
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	// Servers such as MySQL talk first, so their first bytes may be already buffered.
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+
+	return conn, nil
+}
+
+// bufferedConn is a connection whose first bytes have been buffered by a reader
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// Read reads from the buffer first, then from the connection
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// canonicalAddr returns the "host:port" of the proxy URL, with the default port of its scheme
+func canonicalAddr(u *url.URL) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+
+	port := "1080"
+	switch u.Scheme {
+	case "http":
+		port = "80"
+	case "https":
+		port = "443"
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// getEnvAny returns the value of the first environment variable that is set
+func getEnvAny(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,122 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialer
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHJump dials the addresses from an SSH server through direct-tcpip channels, like the
+// ProxyJump option of OpenSSH. It shares a single SSH connection between the dials, and
+// reconnects when the connection is lost.
+type SSHJump struct {
+	address string
+	config  *ssh.ClientConfig
+	forward DialFunc
+
+	mu     sync.Mutex
+	client *ssh.Client
+}
+
+// NewSSHJump creates a new SSHJump to the SSH server of the address, which is reached with forward
+func NewSSHJump(address string, config *ssh.ClientConfig, forward DialFunc) *SSHJump {
+	return &SSHJump{
+		address: address,
+		config:  config,
+		forward: forward,
+	}
+}
+
+// DialContext connects to the address from the SSH server, it's a DialFunc.
+func (j *SSHJump) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	client, err := j.getClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.DialContext(ctx, network, address)
+}
+
+// Close closes the SSH connection, a later dial opens a new one.
+func (j *SSHJump) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.client == nil {
+		return nil
+	}
+
+	err := j.client.Close()
+	j.client = nil
+
+	return err
+}
+
+// getClient returns the shared SSH client, it connects to the SSH server if needed
+func (j *SSHJump) getClient(ctx context.Context) (*ssh.Client, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.client != nil {
+		return j.client, nil
+	}
+
+	conn, err := j.forward(ctx, "tcp", j.address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bound the SSH handshake with the context, as the dial itself does.
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	c, chans, reqs, err := ssh.NewClientConn(conn, j.address, j.config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to connect to the ssh server %s: %w", j.address, err)
+	}
+
+	if err := conn.SetDeadline(time.Time{}); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	client := ssh.NewClient(c, chans, reqs)
+	j.client = client
+
+	// Forget the connection once it's lost, so the next dial reconnects.
+	go func() {
+		_ = client.Wait()
+
+		j.mu.Lock()
+		defer j.mu.Unlock()
+
+		if j.client == client {
+			j.client = nil
+		}
+	}()
+
+	return client, nil
+}
@@ -0,0 +1,153 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialer
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/ssh"
+
+	"wait4x.dev/v3/internal/test"
+)
+
+// SSHJumpSuite is a test suite for the SSH jump dialer
+type SSHJumpSuite struct {
+	suite.Suite
+
+	// Shared resources for the test suite
+	target    net.Listener
+	sshServer *test.SSHServer
+}
+
+// SetupSuite starts the target server
+func (s *SSHJumpSuite) SetupSuite() {
+	var err error
+
+	s.target, err = net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	go serve(s.target, func(conn net.Conn) {
+		conn.Write([]byte(greeting))
+	})
+}
+
+// TearDownSuite stops the target server
+func (s *SSHJumpSuite) TearDownSuite() {
+	s.Require().NoError(s.target.Close())
+}
+
+// SetupTest starts a new SSH server for each test
+func (s *SSHJumpSuite) SetupTest() {
+	var err error
+
+	s.sshServer, err = test.NewSSHServer()
+	s.Require().NoError(err)
+}
+
+// TearDownTest stops the SSH server
+func (s *SSHJumpSuite) TearDownTest() {
+	s.Require().NoError(s.sshServer.Close())
+}
+
+// newJump creates a new SSHJump to the SSH server with the host key callback
+func (s *SSHJumpSuite) newJump(hostKeyCallback ssh.HostKeyCallback) *SSHJump {
+	jump := NewSSHJump(s.sshServer.Addr, &ssh.ClientConfig{
+		User:            "wait4x",
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(s.sshServer.ClientKey)},
+		HostKeyCallback: hostKeyCallback,
+	}, Direct(time.Second))
+	s.T().Cleanup(func() { jump.Close() })
+
+	return jump
+}
+
+// readGreeting reads the greeting of the target server from the connection
+func (s *SSHJumpSuite) readGreeting(conn net.Conn) {
+	defer conn.Close()
+
+	buf := make([]byte, len(greeting))
+	_, err := io.ReadFull(conn, buf)
+	s.Require().NoError(err)
+	s.Equal(greeting, string(buf))
+}
+
+// TestDialReusesConnection tests that the dials share a single SSH connection
+func (s *SSHJumpSuite) TestDialReusesConnection() {
+	jump := s.newJump(ssh.InsecureIgnoreHostKey())
+
+	for range 3 {
+		conn, err := jump.DialContext(context.Background(), "tcp", s.target.Addr().String())
+		s.Require().NoError(err)
+		s.readGreeting(conn)
+	}
+
+	s.Equal(1, s.sshServer.Connections())
+}
+
+// TestDialTargetRefused tests a target that refuses the connection from the SSH server
+func (s *SSHJumpSuite) TestDialTargetRefused() {
+	jump := s.newJump(ssh.InsecureIgnoreHostKey())
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	s.Require().NoError(l.Close())
+
+	_, err = jump.DialContext(context.Background(), "tcp", l.Addr().String())
+	s.Error(err)
+
+	conn, err := jump.DialContext(context.Background(), "tcp", s.target.Addr().String())
+	s.Require().NoError(err)
+	s.readGreeting(conn)
+	s.Equal(1, s.sshServer.Connections())
+}
+
+// TestDialReconnects tests reconnecting once the SSH connection is lost
+func (s *SSHJumpSuite) TestDialReconnects() {
+	jump := s.newJump(ssh.InsecureIgnoreHostKey())
+
+	conn, err := jump.DialContext(context.Background(), "tcp", s.target.Addr().String())
+	s.Require().NoError(err)
+	s.readGreeting(conn)
+
+	s.sshServer.CloseConnections()
+
+	s.Eventually(func() bool {
+		conn, err := jump.DialContext(context.Background(), "tcp", s.target.Addr().String())
+		if err != nil {
+			return false
+		}
+		conn.Close()
+
+		return s.sshServer.Connections() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+// TestDialUnknownHostKey tests an SSH server whose host key isn't the known one
+func (s *SSHJumpSuite) TestDialUnknownHostKey() {
+	jump := s.newJump(ssh.FixedHostKey(s.sshServer.ClientKey.PublicKey()))
+
+	_, err := jump.DialContext(context.Background(), "tcp", s.target.Addr().String())
+	s.ErrorContains(err, "failed to connect to the ssh server")
+	s.Equal(0, s.sshServer.Connections())
+}
+
+// TestSSHJumpSuite runs the test suite
+func TestSSHJumpSuite(t *testing.T) {
+	suite.Run(t, new(SSHJumpSuite))
+}
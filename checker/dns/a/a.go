@@ -20,8 +20,13 @@ import (
 	"net"
 
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+	dns2 "wait4x.dev/v3/checker/dns"
 )
 
+// ObservationIPs is the observation of the resolved IPs, a []string
+const ObservationIPs = "ips"
+
 // Option configures an DNS A records
 type Option func(d *A)
 
@@ -31,13 +36,13 @@ type A struct {
 	address     string
 	expectedIPs []string
 	resolver    *net.Resolver
+	dialer      dialer.DialFunc
 }
 
 // New creates a new DNS A checker for the given address
 func New(address string, opts ...Option) checker.Checker {
 	d := &A{
-		address:  address,
-		resolver: net.DefaultResolver,
+		address: address,
 	}
 
 	// Apply the list of options to A
@@ -45,15 +50,8 @@ func New(address string, opts ...Option) checker.Checker {
 		opt(d)
 	}
 
-	// Nameserver settings.
-	if d.nameserver != "" {
-		d.resolver = &net.Resolver{
-			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
-				dialer := net.Dialer{}
-				return dialer.DialContext(ctx, network, d.nameserver)
-			},
-		}
-	}
+	// Nameserver and dialer settings.
+	d.resolver = dns2.Resolver(d.nameserver, d.dialer)
 
 	return d
 }
@@ -65,6 +63,13 @@ func WithNameServer(nameserver string) Option {
 	}
 }
 
+// WithDialer configures the dialer of the connections to the nameserver
+func WithDialer(d dialer.DialFunc) Option {
+	return func(c *A) {
+		c.dialer = d
+	}
+}
+
 // WithExpectedIPV4s sets expected IPv4s for the DNS A checker
 func WithExpectedIPV4s(ips []string) Option {
 	return func(d *A) {
@@ -77,6 +82,16 @@ func (d *A) Identity() (string, error) {
 	return d.address, nil
 }
 
+// Describe returns the description of the DNS A checker
+func (d *A) Describe() (checker.Description, error) {
+	return dns2.Describe("A", d.address, d.nameserver), nil
+}
+
+// ObservationNames returns the names of the observations of the DNS A checker
+func (d *A) ObservationNames() []string {
+	return []string{ObservationIPs}
+}
+
 // Check checks the DNS A records
 func (d *A) Check(ctx context.Context) (err error) {
 	ips, err := d.resolver.LookupIP(ctx, "ip4", d.address)
@@ -84,6 +99,12 @@ func (d *A) Check(ctx context.Context) (err error) {
 		return err
 	}
 
+	resolved := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		resolved = append(resolved, ip.String())
+	}
+	checker.Observe(ctx, ObservationIPs, resolved)
+
 	for _, ip := range ips {
 		if len(d.expectedIPs) == 0 {
 			return nil
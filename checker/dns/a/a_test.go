@@ -17,10 +17,13 @@ package a
 
 import (
 	"context"
+	"net"
 	"testing"
 
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/suite"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 const (
@@ -63,6 +66,68 @@ func (s *TestSuite) TestCustomNSCorrectA() {
 	s.Assert().Nil(d.Check(context.Background()))
 }
 
+// TestCorrectAWithDialer tests that the A checker resolves over the connection of a custom dialer.
+func (s *TestSuite) TestCorrectAWithDialer() {
+	d := New("in-memory.example", WithNameServer("in-memory:53"), WithExpectedIPV4s([]string{"192.0.2.1"}),
+		WithDialer(func(context.Context, string, string) (net.Conn, error) {
+			client, server := net.Pipe()
+			go func() {
+				defer server.Close()
+				conn := &dns.Conn{Conn: server}
+				m, err := conn.ReadMsg()
+				if err != nil {
+					return
+				}
+				r := new(dns.Msg).SetReply(m)
+				r.Answer = append(r.Answer, &dns.A{
+					Hdr: dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+					A:   net.ParseIP("192.0.2.1"),
+				})
+				conn.WriteMsg(r)
+			}()
+			return client, nil
+		}),
+	)
+	s.Assert().Nil(d.Check(context.Background()))
+}
+
+// TestCheckNoFDLeak tests that the A checks release their sockets to the nameserver
+func (s *TestSuite) TestCheckNoFDLeak() {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	s.Require().NoError(err)
+
+	nameserver := &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, m *dns.Msg) {
+		r := new(dns.Msg).SetReply(m)
+		if m.Question[0].Qtype == dns.TypeA {
+			r.Answer = append(r.Answer, &dns.A{
+				Hdr: dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+				A:   net.ParseIP("192.0.2.1"),
+			})
+		}
+		w.WriteMsg(r)
+	})}
+	go nameserver.ActivateAndServe()
+	defer nameserver.Shutdown()
+
+	d := New("wait4x.example", WithNameServer(conn.LocalAddr().String()), WithExpectedIPV4s([]string{"192.0.2.1"}))
+	test.AssertFDsBounded(s.T(), 200, 10, func() {
+		s.Assert().NoError(d.Check(context.Background()))
+	})
+}
+
+// TestDescribe tests that the record type is the kind of the A checker, e.g. the log prefix
+func (s *TestSuite) TestDescribe() {
+	desc, err := checker.Describe(New(server, WithNameServer("8.8.8.8:53")))
+	s.Require().NoError(err)
+	s.Equal(checker.Description{
+		Kind:     "A",
+		Host:     server,
+		Protocol: "dns",
+		Display:  server,
+		Labels:   map[string]string{"record": "A", "nameserver": "8.8.8.8:53"},
+	}, desc)
+}
+
 // TestA is a test function that runs the TestSuite for the A checker.
 func TestA(t *testing.T) {
 	suite.Run(t, new(TestSuite))
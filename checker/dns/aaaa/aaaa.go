@@ -22,6 +22,7 @@ import (
 	dns2 "wait4x.dev/v3/checker/dns"
 
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 )
 
 // Option configures an DNS AAAA records
@@ -30,6 +31,7 @@ type Option func(d *AAAA)
 // AAAA represents DNS AAAA data structure
 type AAAA struct {
 	nameserver  string
+	dialer      dialer.DialFunc
 	address     string
 	expectedIPs []string
 }
@@ -55,6 +57,13 @@ func WithNameServer(nameserver string) Option {
 	}
 }
 
+// WithDialer configures the dialer of the connections to the nameserver
+func WithDialer(d dialer.DialFunc) Option {
+	return func(c *AAAA) {
+		c.dialer = d
+	}
+}
+
 // WithExpectedIPV6s sets expected IPv6s
 func WithExpectedIPV6s(ips []string) Option {
 	return func(d *AAAA) {
@@ -67,6 +76,11 @@ func (d *AAAA) Identity() (string, error) {
 	return d.address, nil
 }
 
+// Describe returns the description of the DNS AAAA checker
+func (d *AAAA) Describe() (checker.Description, error) {
+	return dns2.Describe("AAAA", d.address, d.nameserver), nil
+}
+
 // Check checks DNS records
 func (d *AAAA) Check(ctx context.Context) (err error) {
 	c := new(dns.Client)
@@ -76,7 +90,7 @@ func (d *AAAA) Check(ctx context.Context) (err error) {
 	m.SetQuestion(dns.Fqdn(d.address), dns.TypeAAAA)
 	m.RecursionDesired = true
 
-	r, _, err := c.ExchangeContext(ctx, m, dns2.RR(d.nameserver))
+	r, err := dns2.Exchange(ctx, c, m, dns2.RR(d.nameserver), d.dialer)
 	if err != nil {
 		return err
 	}
@@ -17,10 +17,13 @@ package aaaa
 
 import (
 	"context"
+	"net"
 	"testing"
 
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/suite"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 const (
@@ -66,6 +69,28 @@ func (s *TestSuite) TestCustomNSCorrectAAAA() {
 	s.Assert().Nil(d.Check(context.Background()))
 }
 
+// TestCheckNoFDLeak tests that the AAAA checks release their sockets to the nameserver
+func (s *TestSuite) TestCheckNoFDLeak() {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	s.Require().NoError(err)
+
+	nameserver := &dns.Server{PacketConn: conn, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, m *dns.Msg) {
+		r := new(dns.Msg).SetReply(m)
+		r.Answer = append(r.Answer, &dns.AAAA{
+			Hdr:  dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeAAAA, Class: dns.ClassINET, Ttl: 60},
+			AAAA: net.ParseIP("2001:db8::1"),
+		})
+		w.WriteMsg(r)
+	})}
+	go nameserver.ActivateAndServe()
+	defer nameserver.Shutdown()
+
+	d := New("wait4x.example", WithNameServer(conn.LocalAddr().String()), WithExpectedIPV6s([]string{"2001:db8::1"}))
+	test.AssertFDsBounded(s.T(), 200, 10, func() {
+		s.Assert().NoError(d.Check(context.Background()))
+	})
+}
+
 // TestAAAA runs the test suite for the AAAA DNS checker.
 func TestAAAA(t *testing.T) {
 	suite.Run(t, new(TestSuite))
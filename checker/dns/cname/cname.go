@@ -23,6 +23,7 @@ import (
 
 	"github.com/miekg/dns"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 	dns2 "wait4x.dev/v3/checker/dns"
 )
 
@@ -32,6 +33,7 @@ type Option func(d *CNAME)
 // CNAME is a DNS CNAME checker
 type CNAME struct {
 	nameserver      string
+	dialer          dialer.DialFunc
 	address         string
 	expectedDomains []string
 }
@@ -57,6 +59,13 @@ func WithNameServer(nameserver string) Option {
 	}
 }
 
+// WithDialer configures the dialer of the connections to the nameserver
+func WithDialer(d dialer.DialFunc) Option {
+	return func(c *CNAME) {
+		c.dialer = d
+	}
+}
+
 // WithExpectedDomains sets expected domains for the DNS CNAME checker
 func WithExpectedDomains(doamins []string) Option {
 	return func(d *CNAME) {
@@ -69,6 +78,11 @@ func (d *CNAME) Identity() (string, error) {
 	return d.address, nil
 }
 
+// Describe returns the description of the DNS CNAME checker
+func (d *CNAME) Describe() (checker.Description, error) {
+	return dns2.Describe("CNAME", d.address, d.nameserver), nil
+}
+
 // Check checks the DNS CNAME records
 func (d *CNAME) Check(ctx context.Context) (err error) {
 	c := new(dns.Client)
@@ -78,7 +92,7 @@ func (d *CNAME) Check(ctx context.Context) (err error) {
 	m.SetQuestion(dns.Fqdn(d.address), dns.TypeCNAME)
 	m.RecursionDesired = true
 
-	r, _, err := c.ExchangeContext(ctx, m, dns2.RR(d.nameserver))
+	r, err := dns2.Exchange(ctx, c, m, dns2.RR(d.nameserver), d.dialer)
 	if err != nil {
 		return err
 	}
@@ -17,8 +17,10 @@ package cname
 
 import (
 	"context"
+	"net"
 	"testing"
 
+	"github.com/miekg/dns"
 	"github.com/stretchr/testify/suite"
 	"wait4x.dev/v3/checker"
 )
@@ -63,6 +65,34 @@ func (s *TestSuite) TestRegexCorrectCNAME() {
 	s.Assert().Nil(d.Check(context.Background()))
 }
 
+// TestCNAMEWithDialer tests that the CNAME DNS check queries the nameserver over the connection of a custom dialer.
+func (s *TestSuite) TestCNAMEWithDialer() {
+	var dialed string
+	d := New(server, WithNameServer("in-memory:53"), WithExpectedDomains([]string{"target.example.com"}),
+		WithDialer(func(_ context.Context, _, address string) (net.Conn, error) {
+			dialed = address
+			client, server := net.Pipe()
+			go func() {
+				defer server.Close()
+				conn := &dns.Conn{Conn: server}
+				m, err := conn.ReadMsg()
+				if err != nil {
+					return
+				}
+				r := new(dns.Msg).SetReply(m)
+				r.Answer = append(r.Answer, &dns.CNAME{
+					Hdr:    dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeCNAME, Class: dns.ClassINET, Ttl: 60},
+					Target: "target.example.com.",
+				})
+				conn.WriteMsg(r)
+			}()
+			return client, nil
+		}),
+	)
+	s.Assert().Nil(d.Check(context.Background()))
+	s.Assert().Equal("in-memory:53", dialed)
+}
+
 // TestCNAME runs the test suite for CNAME DNS checks.
 func TestCNAME(t *testing.T) {
 	suite.Run(t, new(TestSuite))
@@ -16,11 +16,15 @@
 package dns
 
 import (
+	"context"
 	"net"
 	"os"
 	"time"
 
 	"github.com/miekg/dns"
+
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 )
 
 // DefaultTimeout is the default timeout for DNS requests
@@ -29,6 +33,18 @@ var DefaultTimeout = 5 * time.Second
 // defaultRR is the default DNS resolver address
 var defaultRR = "1.1.1.1:53" // Cloudflare DNS resolver
 
+// Describe returns the description of a checker of the DNS record type for the address, the record
+// type is its kind, e.g. "A" or "MX"
+func Describe(record, address, nameserver string) checker.Description {
+	desc := checker.DescribeAddress(record, "dns", address)
+	desc.Labels = map[string]string{"record": record}
+	if nameserver != "" {
+		desc.Labels["nameserver"] = nameserver
+	}
+
+	return desc
+}
+
 // RR returns the default DNS resolver address, or the first resolver address
 // from the system's resolv.conf file if it exists and is readable
 func RR(nameserver string) string {
@@ -48,3 +64,46 @@ func RR(nameserver string) string {
 
 	return net.JoinHostPort(conf.Servers[0], conf.Port)
 }
+
+// Resolver returns the resolver of the nameserver and the dialer, or the default resolver when both are empty
+func Resolver(nameserver string, dial dialer.DialFunc) *net.Resolver {
+	if nameserver == "" && dial == nil {
+		return net.DefaultResolver
+	}
+
+	return &net.Resolver{
+		// The dialer is only used by the Go resolver.
+		PreferGo: dial != nil,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			if nameserver != "" {
+				address = nameserver
+			}
+
+			return dialer.WithTimeout(dial, 0)(ctx, network, address)
+		},
+	}
+}
+
+// Exchange sends the message to the nameserver address with the client, over a connection of the dialer when it's not nil
+func Exchange(ctx context.Context, c *dns.Client, m *dns.Msg, address string, dial dialer.DialFunc) (*dns.Msg, error) {
+	if dial == nil {
+		r, _, err := c.ExchangeContext(ctx, m, address)
+		return r, err
+	}
+
+	network := c.Net
+	if network == "" {
+		network = "udp"
+	}
+
+	conn, err := dialer.WithTimeout(dial, c.Timeout)(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	// The connection is framed as TCP unless it's a packet connection.
+	r, _, err := c.ExchangeWithConnContext(ctx, m, &dns.Conn{Conn: conn})
+
+	return r, err
+}
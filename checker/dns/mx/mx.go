@@ -22,6 +22,8 @@ import (
 	"regexp"
 
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+	dns2 "wait4x.dev/v3/checker/dns"
 )
 
 // Option configures an DNS MX records
@@ -33,13 +35,13 @@ type MX struct {
 	address         string
 	expectedDomains []string
 	resolver        *net.Resolver
+	dialer          dialer.DialFunc
 }
 
 // New creates a new DNS MX checker
 func New(address string, opts ...Option) checker.Checker {
 	d := &MX{
-		address:  address,
-		resolver: net.DefaultResolver,
+		address: address,
 	}
 
 	// apply the list of options to MX
@@ -47,15 +49,8 @@ func New(address string, opts ...Option) checker.Checker {
 		opt(d)
 	}
 
-	// Nameserver settings.
-	if d.nameserver != "" {
-		d.resolver = &net.Resolver{
-			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
-				dialer := net.Dialer{}
-				return dialer.DialContext(ctx, network, d.nameserver)
-			},
-		}
-	}
+	// Nameserver and dialer settings.
+	d.resolver = dns2.Resolver(d.nameserver, d.dialer)
 
 	return d
 }
@@ -67,6 +62,13 @@ func WithNameServer(nameserver string) Option {
 	}
 }
 
+// WithDialer configures the dialer of the connections to the nameserver
+func WithDialer(d dialer.DialFunc) Option {
+	return func(c *MX) {
+		c.dialer = d
+	}
+}
+
 // WithExpectedDomains sets expected domains for the DNS MX checker
 func WithExpectedDomains(domains []string) Option {
 	return func(d *MX) {
@@ -79,6 +81,11 @@ func (d *MX) Identity() (string, error) {
 	return fmt.Sprintf("MX %s %s", d.address, d.expectedDomains), nil
 }
 
+// Describe returns the description of the DNS MX checker
+func (d *MX) Describe() (checker.Description, error) {
+	return dns2.Describe("MX", d.address, d.nameserver), nil
+}
+
 // Check checks the DNS MX records
 func (d *MX) Check(ctx context.Context) (err error) {
 	values, err := d.resolver.LookupMX(ctx, d.address)
@@ -21,6 +21,8 @@ import (
 	"regexp"
 
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+	dns2 "wait4x.dev/v3/checker/dns"
 )
 
 // Option configures an DNS NS records
@@ -32,13 +34,13 @@ type NS struct {
 	address             string
 	expectedNameservers []string
 	resolver            *net.Resolver
+	dialer              dialer.DialFunc
 }
 
 // New creates a new DNS NS checker
 func New(address string, opts ...Option) checker.Checker {
 	d := &NS{
-		address:  address,
-		resolver: net.DefaultResolver,
+		address: address,
 	}
 
 	// apply the list of options to NS
@@ -46,15 +48,8 @@ func New(address string, opts ...Option) checker.Checker {
 		opt(d)
 	}
 
-	// Nameserver settings.
-	if d.nameserver != "" {
-		d.resolver = &net.Resolver{
-			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
-				dialer := net.Dialer{}
-				return dialer.DialContext(ctx, network, d.nameserver)
-			},
-		}
-	}
+	// Nameserver and dialer settings.
+	d.resolver = dns2.Resolver(d.nameserver, d.dialer)
 
 	return d
 }
@@ -66,6 +61,13 @@ func WithNameServer(nameserver string) Option {
 	}
 }
 
+// WithDialer configures the dialer of the connections to the nameserver
+func WithDialer(d dialer.DialFunc) Option {
+	return func(c *NS) {
+		c.dialer = d
+	}
+}
+
 // WithExpectedNameservers sets expected nameservers for the DNS NS checker
 func WithExpectedNameservers(nameservers []string) Option {
 	return func(d *NS) {
@@ -78,6 +80,11 @@ func (d *NS) Identity() (string, error) {
 	return d.address, nil
 }
 
+// Describe returns the description of the DNS NS checker
+func (d *NS) Describe() (checker.Description, error) {
+	return dns2.Describe("NS", d.address, d.nameserver), nil
+}
+
 // Check checks the DNS NS records
 func (d *NS) Check(ctx context.Context) (err error) {
 	values, err := d.resolver.LookupNS(ctx, d.address)
@@ -21,6 +21,8 @@ import (
 	"regexp"
 
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+	dns2 "wait4x.dev/v3/checker/dns"
 )
 
 // Option configures an DNS TXT records
@@ -32,13 +34,13 @@ type TXT struct {
 	address        string
 	expectedValues []string
 	resolver       *net.Resolver
+	dialer         dialer.DialFunc
 }
 
 // New creates a new DNS TXT checker
 func New(address string, opts ...Option) checker.Checker {
 	d := &TXT{
-		address:  address,
-		resolver: net.DefaultResolver,
+		address: address,
 	}
 
 	// apply the list of options to TXT
@@ -46,15 +48,8 @@ func New(address string, opts ...Option) checker.Checker {
 		opt(d)
 	}
 
-	// Nameserver settings.
-	if d.nameserver != "" {
-		d.resolver = &net.Resolver{
-			Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
-				dialer := net.Dialer{}
-				return dialer.DialContext(ctx, network, d.nameserver)
-			},
-		}
-	}
+	// Nameserver and dialer settings.
+	d.resolver = dns2.Resolver(d.nameserver, d.dialer)
 
 	return d
 }
@@ -66,6 +61,13 @@ func WithNameServer(nameserver string) Option {
 	}
 }
 
+// WithDialer configures the dialer of the connections to the nameserver
+func WithDialer(d dialer.DialFunc) Option {
+	return func(c *TXT) {
+		c.dialer = d
+	}
+}
+
 // WithExpectedValues sets expected values for the DNS TXT checker
 func WithExpectedValues(values []string) Option {
 	return func(d *TXT) {
@@ -78,6 +80,11 @@ func (d *TXT) Identity() (string, error) {
 	return d.address, nil
 }
 
+// Describe returns the description of the DNS TXT checker
+func (d *TXT) Describe() (checker.Description, error) {
+	return dns2.Describe("TXT", d.address, d.nameserver), nil
+}
+
 // Check checks the DNS TXT records
 func (d *TXT) Check(ctx context.Context) (err error) {
 	values, err := d.resolver.LookupTXT(ctx, d.address)
@@ -34,9 +34,9 @@ func NewExpectedError(msg string, cause error, details ...any) error {
 	return ee
 }
 
-// Details returns the error details
+// Details returns the error details, with the secrets redacted
 func (ee *ExpectedError) Details() []any {
-	return ee.details
+	return RedactDetails(ee.details)
 }
 
 func (ee *ExpectedError) Unwrap() error {
@@ -45,8 +45,8 @@ func (ee *ExpectedError) Unwrap() error {
 
 func (ee *ExpectedError) Error() string {
 	if ee.cause != nil {
-		return fmt.Sprintf("%s, caused by: %s", ee.msg, ee.cause.Error())
+		return Redact(fmt.Sprintf("%s, caused by: %s", ee.msg, ee.cause.Error()))
 	}
 
-	return ee.msg
+	return Redact(ee.msg)
 }
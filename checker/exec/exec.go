@@ -71,6 +71,20 @@ func (e *Exec) Identity() (string, error) {
 	return e.command, nil
 }
 
+// Describe returns the description of the Exec checker
+func (e *Exec) Describe() (checker.Description, error) {
+	identity, err := e.Identity()
+	if err != nil {
+		return checker.Description{}, err
+	}
+
+	return checker.Description{
+		Kind:    "Exec",
+		Host:    e.command,
+		Display: identity,
+	}, nil
+}
+
 // Check executes the command and checks if it returns the expected exit code
 func (e *Exec) Check(ctx context.Context) error {
 	// Create command with context for cancellation support
@@ -0,0 +1,197 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+)
+
+// ErrEmptyBearerToken defines the error of an empty bearer token file
+var ErrEmptyBearerToken = errors.New("empty bearer token")
+
+// WithBasicAuth configures the basic authentication of the requests, see checker.AddSecrets to redact the password
+func WithBasicAuth(username, password string) Option {
+	return func(h *HTTP) {
+		h.basicAuth = &basicAuth{username: username, password: password}
+	}
+}
+
+// WithBearerToken configures the bearer token of the requests
+func WithBearerToken(token string) Option {
+	return WithTokenSource(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}))
+}
+
+// WithBearerTokenFile configures the file of the bearer token of the requests, it's read again
+// on every attempt, so a rotated token is picked up.
+func WithBearerTokenFile(path string) Option {
+	return WithTokenSource(fileTokenSource(path))
+}
+
+// WithOAuth2ClientCredentials configures the OAuth2 client credentials flow of the requests, the
+// token is cached and refreshed when it expires. The token requests share the TLS config, the proxy and
+// the dialer of the checker, but not its Unix socket, HTTP/3 and address overrides of the target.
+// See checker.AddSecrets to redact the client secret.
+func WithOAuth2ClientCredentials(config *clientcredentials.Config) Option {
+	return func(h *HTTP) {
+		var (
+			once   sync.Once
+			source oauth2.TokenSource
+			err    error
+		)
+		h.tokenSource = newRedactedTokenSource(tokenSourceFunc(func() (*oauth2.Token, error) {
+			once.Do(func() {
+				var client *http.Client
+				client, err = h.newTokenClient()
+				if err != nil {
+					return
+				}
+				h.tokenClient = client
+
+				ctx := context.WithValue(context.Background(), oauth2.HTTPClient, client)
+				source = config.TokenSource(ctx)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return source.Token()
+		}))
+	}
+}
+
+// WithTokenSource configures the source of the bearer tokens of the requests, the tokens are redacted
+func WithTokenSource(source oauth2.TokenSource) Option {
+	return func(h *HTTP) {
+		h.tokenSource = newRedactedTokenSource(source)
+	}
+}
+
+// newTokenClient creates the client of the token requests. The token URL is another server than the
+// target, so the client only shares the TLS config, without the server name, the proxy and the dialer.
+func (h *HTTP) newTokenClient() (*http.Client, error) {
+	tlsConfig, err := h.getTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		tlsConfig.ServerName = ""
+	}
+
+	return &http.Client{
+		Timeout: h.timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+			Proxy:           h.proxy,
+			DialContext:     dialer.WithTimeout(h.dialer, h.timeout),
+		},
+	}, nil
+}
+
+// basicAuth is the username and the password of the basic authentication
+type basicAuth struct {
+	username, password string
+}
+
+// tokenSourceFunc is a function that returns a token
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+// Token returns the token of the function
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+// redactedTokenSource is a token source that adds each new token of its source to the secrets
+type redactedTokenSource struct {
+	source oauth2.TokenSource
+
+	mu   sync.Mutex
+	last string
+}
+
+// newRedactedTokenSource returns the redacted token source of the source
+func newRedactedTokenSource(source oauth2.TokenSource) oauth2.TokenSource {
+	if source == nil {
+		return nil
+	}
+
+	return &redactedTokenSource{source: source}
+}
+
+// Token returns the token of the source, it's added to the secrets when it's new, not on every attempt
+func (s *redactedTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.source.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The tokens may end up in the errors, e.g. of the redirects.
+	if token.AccessToken != s.last {
+		checker.AddSecrets(token.AccessToken)
+		s.last = token.AccessToken
+	}
+
+	return token, nil
+}
+
+// fileTokenSource returns the token source of the file
+func fileTokenSource(path string) oauth2.TokenSource {
+	return tokenSourceFunc(func() (*oauth2.Token, error) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		token := strings.TrimSpace(string(content))
+		if token == "" {
+			return nil, fmt.Errorf("%w in %s", ErrEmptyBearerToken, path)
+		}
+
+		return &oauth2.Token{AccessToken: token}, nil
+	})
+}
+
+// authenticate sets the credentials of the request
+func (h *HTTP) authenticate(req *http.Request) error {
+	if h.basicAuth != nil {
+		req.SetBasicAuth(h.basicAuth.username, h.basicAuth.password)
+	}
+
+	if h.tokenSource == nil {
+		return nil
+	}
+
+	token, err := h.tokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("can't get the bearer token: %w", checker.RedactError(err))
+	}
+
+	token.SetAuthHeader(req)
+
+	return nil
+}
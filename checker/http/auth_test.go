@@ -0,0 +1,190 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/clientcredentials"
+	"wait4x.dev/v3/checker"
+)
+
+// authorizationServer returns a server that records the authorization headers of the requests
+func authorizationServer(t *testing.T) (*httptest.Server, *[]string) {
+	var authorizations []string
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	t.Cleanup(ts.Close)
+
+	return ts, &authorizations
+}
+
+// TestHttpBasicAuth tests the basic authentication of the requests
+func TestHttpBasicAuth(t *testing.T) {
+	ts, authorizations := authorizationServer(t)
+
+	hc := New(ts.URL, WithBasicAuth("admin", "basic-auth-password"))
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.Equal(t, []string{"Basic YWRtaW46YmFzaWMtYXV0aC1wYXNzd29yZA=="}, *authorizations)
+	// Only the callers register the secrets, building a checker has no side effects.
+	assert.Contains(t, checker.Redact("secret basic-auth-password"), "basic-auth-password")
+}
+
+// TestHttpBearerToken tests the static bearer token of the requests
+func TestHttpBearerToken(t *testing.T) {
+	ts, authorizations := authorizationServer(t)
+
+	hc := New(ts.URL, WithBearerToken("static-bearer-token"))
+	for range 2 {
+		assert.NoError(t, hc.Check(context.Background()))
+	}
+	assert.Equal(t, []string{"Bearer static-bearer-token", "Bearer static-bearer-token"}, *authorizations)
+	assert.NotContains(t, checker.Redact("token static-bearer-token"), "static-bearer-token")
+}
+
+// TestHttpBearerTokenFile tests that the bearer token file is read again on every attempt
+func TestHttpBearerTokenFile(t *testing.T) {
+	ts, authorizations := authorizationServer(t)
+
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first-file-token\n"), 0o600))
+
+	hc := New(ts.URL, WithBearerTokenFile(path))
+	assert.NoError(t, hc.Check(context.Background()))
+
+	require.NoError(t, os.WriteFile(path, []byte("second-file-token"), 0o600))
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.Equal(t, []string{"Bearer first-file-token", "Bearer second-file-token"}, *authorizations)
+
+	require.NoError(t, os.WriteFile(path, []byte(" \n"), 0o600))
+	assert.ErrorIs(t, hc.Check(context.Background()), ErrEmptyBearerToken)
+
+	require.NoError(t, os.Remove(path))
+	assert.ErrorIs(t, hc.Check(context.Background()), os.ErrNotExist)
+	assert.Len(t, *authorizations, 2)
+}
+
+// TestHttpOAuth2ClientCredentials tests that the client credentials token is cached and refreshed
+func TestHttpOAuth2ClientCredentials(t *testing.T) {
+	var issued atomic.Int32
+	expiresIn := 3600
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil ||
+			r.PostForm.Get("grant_type") != "client_credentials" || r.PostForm.Get("scope") != "health:read" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if id, secret, ok := r.BasicAuth(); !ok || id != "wait4x" || secret != "client-credentials-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token": "oauth2-token-%d", "token_type": "Bearer", "expires_in": %d}`,
+			issued.Add(1), expiresIn)
+	}))
+	defer tokenServer.Close()
+
+	ts, authorizations := authorizationServer(t)
+
+	config := &clientcredentials.Config{
+		ClientID:     "wait4x",
+		ClientSecret: "client-credentials-secret",
+		TokenURL:     tokenServer.URL,
+		Scopes:       []string{"health:read"},
+	}
+
+	hc := New(ts.URL, WithOAuth2ClientCredentials(config))
+	for range 3 {
+		assert.NoError(t, hc.Check(context.Background()))
+	}
+	assert.Equal(t, int32(1), issued.Load())
+	assert.Equal(t, []string{"Bearer oauth2-token-1", "Bearer oauth2-token-1", "Bearer oauth2-token-1"}, *authorizations)
+	assert.Contains(t, checker.Redact("secret client-credentials-secret"), "client-credentials-secret")
+	assert.NoError(t, hc.(*HTTP).Close())
+
+	// The tokens that expire within the expiry delta are refreshed on every attempt.
+	expiresIn = 1
+	*authorizations = nil
+	hc = New(ts.URL, WithOAuth2ClientCredentials(config))
+	for range 2 {
+		assert.NoError(t, hc.Check(context.Background()))
+	}
+	assert.Equal(t, int32(3), issued.Load())
+	assert.Equal(t, []string{"Bearer oauth2-token-2", "Bearer oauth2-token-3"}, *authorizations)
+
+	config.ClientSecret = "wrong-client-secret"
+	var expectedError *checker.ExpectedError
+	err := New(ts.URL, WithOAuth2ClientCredentials(config)).Check(context.Background())
+	assert.ErrorContains(t, err, "can't get the bearer token")
+	assert.False(t, errors.As(err, &expectedError))
+}
+
+// TestHttpOAuth2TokenClient tests that the token requests don't go to the Unix socket or the overridden address of the target
+func TestHttpOAuth2TokenClient(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "unix-oauth2-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer tokenServer.Close()
+
+	socket := unixServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer unix-oauth2-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+
+	config := &clientcredentials.Config{ClientID: "wait4x", ClientSecret: "unix-client-secret", TokenURL: tokenServer.URL}
+
+	hc := New("unix://"+socket+":/health", WithOAuth2ClientCredentials(config), WithExpectStatusCode(http.StatusOK))
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.NoError(t, hc.(*HTTP).Close())
+
+	hc = New("http://app.local/health", WithUnixSocket(socket), WithOAuth2ClientCredentials(config), WithExpectStatusCode(http.StatusOK))
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.NoError(t, hc.(*HTTP).Close())
+
+	// The token server isn't redirected to the address of the target either.
+	app := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer unix-oauth2-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer app.Close()
+
+	tokenURL, err := url.Parse(tokenServer.URL)
+	require.NoError(t, err)
+	appURL, err := url.Parse(app.URL)
+	require.NoError(t, err)
+
+	hc = New(tokenServer.URL, WithConnectTo(ConnectTo{
+		Host: tokenURL.Hostname(), Port: tokenURL.Port(), TargetHost: appURL.Hostname(), TargetPort: appURL.Port(),
+	}),
+		WithOAuth2ClientCredentials(config), WithExpectStatusCode(http.StatusOK))
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.Contains(t, checker.Redact("token unix-oauth2-token"), checker.Redacted)
+}
@@ -0,0 +1,138 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidConnectTo defines the error of an invalid resolve or connect-to rule
+var ErrInvalidConnectTo = errors.New("invalid connect-to rule")
+
+// ConnectTo redirects the connections to a host and port to another host and port, without changing
+// the URL, so the Host header, the SNI and the certificate validation keep the host of the URL. The
+// empty host or port matches any, and the empty target host or port keeps the original one.
+type ConnectTo struct {
+	Host       string
+	Port       string
+	TargetHost string
+	TargetPort string
+}
+
+// ParseResolve parses a host:port:address rule, like the --resolve option of curl
+func ParseResolve(value string) (ConnectTo, error) {
+	host, rest, _ := cutField(value)
+	port, address, found := cutField(rest)
+	if !found || host == "" || port == "" {
+		return ConnectTo{}, fmt.Errorf("%w %q: expected host:port:address", ErrInvalidConnectTo, value)
+	}
+
+	address = strings.TrimSuffix(strings.TrimPrefix(address, "["), "]")
+	if net.ParseIP(address) == nil {
+		return ConnectTo{}, fmt.Errorf("%w %q: invalid IP address %q", ErrInvalidConnectTo, value, address)
+	}
+
+	rule := ConnectTo{Host: host, Port: port, TargetHost: address}
+	return rule, rule.validate(value)
+}
+
+// ParseConnectTo parses a host:port:target-host:target-port rule, like the --connect-to option of curl
+func ParseConnectTo(value string) (ConnectTo, error) {
+	var fields [4]string
+	rest, found := value, true
+	for i := range fields {
+		if !found {
+			return ConnectTo{}, fmt.Errorf("%w %q: expected host:port:target-host:target-port", ErrInvalidConnectTo, value)
+		}
+		fields[i], rest, found = cutField(rest)
+	}
+	if found {
+		return ConnectTo{}, fmt.Errorf("%w %q: expected host:port:target-host:target-port", ErrInvalidConnectTo, value)
+	}
+
+	rule := ConnectTo{Host: fields[0], Port: fields[1], TargetHost: fields[2], TargetPort: fields[3]}
+	return rule, rule.validate(value)
+}
+
+// validate checks the ports of the rule
+func (c ConnectTo) validate(value string) error {
+	for _, port := range []string{c.Port, c.TargetPort} {
+		if port == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return fmt.Errorf("%w %q: invalid port %q", ErrInvalidConnectTo, value, port)
+		}
+	}
+
+	return nil
+}
+
+// apply returns the target address of the host and port, and whether the rule matches them
+func (c ConnectTo) apply(host, port string) (string, bool) {
+	if (c.Host != "" && !strings.EqualFold(c.Host, host)) || (c.Port != "" && c.Port != port) {
+		return "", false
+	}
+
+	if c.TargetHost != "" {
+		host = c.TargetHost
+	}
+	if c.TargetPort != "" {
+		port = c.TargetPort
+	}
+
+	return net.JoinHostPort(host, port), true
+}
+
+// cutField cuts the first field of a colon separated value, an IPv6 address field is in brackets
+func cutField(value string) (field, rest string, found bool) {
+	if strings.HasPrefix(value, "[") {
+		if end := strings.Index(value, "]"); end > 0 {
+			field, rest = value[1:end], value[end+1:]
+			if rest == "" {
+				return field, "", false
+			}
+			if strings.HasPrefix(rest, ":") {
+				return field, rest[1:], true
+			}
+		}
+	}
+
+	return strings.Cut(value, ":")
+}
+
+// overrideAddr returns the address that is dialed instead of the address, the connect-to rules
+// are applied first, then the resolve rules, like curl.
+func (h *HTTP) overrideAddr(addr string) string {
+	for _, rules := range [][]ConnectTo{h.connectTo, h.resolve} {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return addr
+		}
+
+		for _, rule := range rules {
+			if target, ok := rule.apply(host, port); ok {
+				addr = target
+				break
+			}
+		}
+	}
+
+	return addr
+}
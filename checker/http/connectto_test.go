@@ -0,0 +1,109 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseResolve tests parsing the resolve rules
+func TestParseResolve(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected ConnectTo
+	}{
+		{"example.com:443:10.0.0.1", ConnectTo{Host: "example.com", Port: "443", TargetHost: "10.0.0.1"}},
+		{"example.com:443:[2001:db8::1]", ConnectTo{Host: "example.com", Port: "443", TargetHost: "2001:db8::1"}},
+		{"example.com:443:2001:db8::1", ConnectTo{Host: "example.com", Port: "443", TargetHost: "2001:db8::1"}},
+		{"[::1]:8080:127.0.0.1", ConnectTo{Host: "::1", Port: "8080", TargetHost: "127.0.0.1"}},
+	}
+	for _, tt := range tests {
+		rule, err := ParseResolve(tt.value)
+		assert.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, rule, tt.value)
+	}
+
+	for _, value := range []string{"", "example.com", "example.com:443", "example.com:443:lb.example.com", ":443:10.0.0.1", "example.com:http:10.0.0.1"} {
+		_, err := ParseResolve(value)
+		assert.ErrorIs(t, err, ErrInvalidConnectTo, value)
+	}
+}
+
+// TestParseConnectTo tests parsing the connect-to rules
+func TestParseConnectTo(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected ConnectTo
+	}{
+		{"example.com:443:lb.example.com:8443", ConnectTo{Host: "example.com", Port: "443", TargetHost: "lb.example.com", TargetPort: "8443"}},
+		{"example.com::lb.example.com:", ConnectTo{Host: "example.com", TargetHost: "lb.example.com"}},
+		{"::[2001:db8::1]:", ConnectTo{TargetHost: "2001:db8::1"}},
+		{":::8443", ConnectTo{TargetPort: "8443"}},
+	}
+	for _, tt := range tests {
+		rule, err := ParseConnectTo(tt.value)
+		assert.NoError(t, err, tt.value)
+		assert.Equal(t, tt.expected, rule, tt.value)
+	}
+
+	for _, value := range []string{"", "example.com:443", "example.com:443:lb.example.com", "a:1:b:2:c", "example.com:443:lb.example.com:0"} {
+		_, err := ParseConnectTo(value)
+		assert.ErrorIs(t, err, ErrInvalidConnectTo, value)
+	}
+}
+
+// TestHttpResolveAndConnectTo tests that the rules redirect the connections, and keep the
+// Host header and the TLS validation of the host of the URL
+func TestHttpResolveAndConnectTo(t *testing.T) {
+	var host string
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		host = r.Host
+	}))
+	defer ts.Close()
+
+	_, port, err := net.SplitHostPort(ts.Listener.Addr().String())
+	require.NoError(t, err)
+	tlsConfig := WithTLSConfig(ts.Client().Transport.(*http.Transport).TLSClientConfig)
+
+	// The certificate of the test server is valid for example.com.
+	resolve, err := ParseResolve("example.com:" + port + ":127.0.0.1")
+	require.NoError(t, err)
+	assert.NoError(t, New("https://example.com:"+port, tlsConfig, WithResolve(resolve)).Check(context.Background()))
+	assert.Equal(t, "example.com:"+port, host)
+
+	connectTo, err := ParseConnectTo("example.com:443:127.0.0.1:" + port)
+	require.NoError(t, err)
+	assert.NoError(t, New("https://example.com/health", tlsConfig, WithConnectTo(connectTo)).Check(context.Background()))
+	assert.Equal(t, "example.com", host)
+
+	// The connect-to rules are applied before the resolve rules.
+	connectTo, err = ParseConnectTo("example.com:443:lb.example.com:" + port)
+	require.NoError(t, err)
+	resolve, err = ParseResolve("lb.example.com:" + port + ":127.0.0.1")
+	require.NoError(t, err)
+	assert.NoError(t, New("https://example.com", tlsConfig, WithConnectTo(connectTo), WithResolve(resolve)).Check(context.Background()))
+
+	// The certificate isn't valid for the other hosts.
+	resolve, err = ParseResolve("wait4x.invalid:" + port + ":127.0.0.1")
+	require.NoError(t, err)
+	assert.ErrorContains(t, New("https://wait4x.invalid:"+port, tlsConfig, WithResolve(resolve)).Check(context.Background()), "certificate")
+}
@@ -16,10 +16,12 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
-	"crypto/x509"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net"
 	"net/http"
@@ -27,12 +29,18 @@ import (
 	"os"
 	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/antchfx/htmlquery"
+	"github.com/santhosh-tekuri/jsonschema/v6"
 	"github.com/tidwall/gjson"
 	"golang.org/x/net/http2"
+	"golang.org/x/oauth2"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+	"wait4x.dev/v3/checker/tlsconfig"
 )
 
 // Option configures an HTTP.
@@ -45,25 +53,73 @@ const (
 	DefaultInsecureSkipTLSVerify = false
 	// DefaultNoRedirect is the default auto redirect
 	DefaultNoRedirect = false
+	// DefaultMaxBodySize is the default maximum size of a response body that is read for the expectations
+	DefaultMaxBodySize = 1 << 20
 )
 
+// The observations of the HTTP checker
+const (
+	// ObservationStatusCode is the status code of the response, an int
+	ObservationStatusCode = "status_code"
+	// ObservationResponseTime is the time until the headers of the response, a time.Duration
+	ObservationResponseTime = "response_time"
+	// ObservationBody is the body of the response, a string, only observed when it's requested
+	ObservationBody = "body"
+	// ObservationJSON is the decoded JSON body of the response, only observed when it's requested
+	ObservationJSON = "json"
+	// ObservationDNSTime is the time of the DNS lookup, a time.Duration, only observed on a new connection
+	ObservationDNSTime = "dns_time"
+	// ObservationConnectTime is the time of the TCP connect, a time.Duration, only observed on a new connection
+	ObservationConnectTime = "connect_time"
+	// ObservationTLSTime is the time of the TLS handshake, a time.Duration, only observed on a new connection
+	ObservationTLSTime = "tls_time"
+	// ObservationFirstByteTime is the time until the first byte of the response, a time.Duration
+	ObservationFirstByteTime = "first_byte_time"
+	// ObservationTotalTime is the time of the whole request, including the read body, a time.Duration
+	ObservationTotalTime = "total_time"
+)
+
+// drainLimit is the maximum size of a response body that is read to reuse its connection
+const drainLimit = 64 << 10
+
 // HTTP is an HTTP checker
 type HTTP struct {
-	address               string
-	timeout               time.Duration
-	expectBodyRegex       string
-	expectBodyJSON        string
-	expectBodyXPath       string
-	expectHeader          string
-	requestHeaders        http.Header
-	requestBody           io.Reader
-	expectStatusCode      int
-	insecureSkipTLSVerify bool
-	noRedirect            bool
-	caFile                string
-	certFile              string
-	keyFile               string
-	h2c                   bool
+	address                 string
+	timeout                 time.Duration
+	expectBodyRegex         []string
+	expectBodyJSON          []string
+	expectBodyXPath         []string
+	expectHeader            []string
+	expectBodyJSONSchema    *jsonSchema
+	maxBodySize             int64
+	expectResponseTime      time.Duration
+	responseTimeConsecutive int
+	fastResponses           atomic.Int64
+	requestHeaders          http.Header
+	requestBody             func() (io.ReadCloser, error)
+	requestMethod           string
+	basicAuth               *basicAuth
+	tokenSource             oauth2.TokenSource
+	tokenClient             *http.Client
+	expectStatusCode        *StatusCodeMatcher
+	insecureSkipTLSVerify   bool
+	noRedirect              bool
+	caFile                  string
+	certFile                string
+	keyFile                 string
+	h2c                     bool
+	http3                   bool
+	allAddresses            bool
+	ipFamily                checker.IPFamily
+	resolve                 []ConnectTo
+	connectTo               []ConnectTo
+	unixSocket              string
+	requestURL              string
+	tlsConfig               *tls.Config
+	dialer                  dialer.DialFunc
+	proxy                   func(*http.Request) (*url.URL, error)
+	persistent              bool
+	client                  *checker.PersistentClient[*http.Client]
 }
 
 // New creates the HTTP checker
@@ -73,6 +129,8 @@ func New(address string, opts ...Option) checker.Checker {
 		timeout:               DefaultConnectionTimeout,
 		insecureSkipTLSVerify: DefaultInsecureSkipTLSVerify,
 		noRedirect:            DefaultNoRedirect,
+		maxBodySize:           DefaultMaxBodySize,
+		proxy:                 http.ProxyFromEnvironment,
 	}
 
 	// apply the list of options to HTTP
@@ -80,6 +138,13 @@ func New(address string, opts ...Option) checker.Checker {
 		opt(h)
 	}
 
+	h.requestURL = address
+	if socket, requestURL, ok := parseUnixTarget(address); ok {
+		h.unixSocket, h.requestURL = socket, requestURL
+	}
+
+	h.client = checker.NewPersistentClient(h.persistent, closeClient)
+
 	return h
 }
 
@@ -90,34 +155,92 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
-// WithExpectBodyRegex configures response body expectation
-func WithExpectBodyRegex(regex string) Option {
+// WithExpectBodyRegex configures response body expectations, all of the regexes must match
+func WithExpectBodyRegex(regexes ...string) Option {
+	return func(h *HTTP) {
+		h.expectBodyRegex = appendNonEmpty(h.expectBodyRegex, regexes)
+	}
+}
+
+// WithExpectBodyJSON configures response json expectations, all of the paths must exist
+func WithExpectBodyJSON(paths ...string) Option {
+	return func(h *HTTP) {
+		h.expectBodyJSON = appendNonEmpty(h.expectBodyJSON, paths)
+	}
+}
+
+// WithExpectBodyXPath configures response xpath expectations, all of the xpaths must match
+func WithExpectBodyXPath(xpaths ...string) Option {
+	return func(h *HTTP) {
+		h.expectBodyXPath = appendNonEmpty(h.expectBodyXPath, xpaths)
+	}
+}
+
+// WithExpectHeader configures response header expectations, all of the headers must match
+func WithExpectHeader(headers ...string) Option {
 	return func(h *HTTP) {
-		h.expectBodyRegex = regex
+		h.expectHeader = appendNonEmpty(h.expectHeader, headers)
 	}
 }
 
-// WithExpectBodyJSON configures response json expectation
-func WithExpectBodyJSON(json string) Option {
+// WithExpectBodyJSONSchema configures response body expectation of the JSON schema file
+func WithExpectBodyJSONSchema(path string) Option {
 	return func(h *HTTP) {
-		h.expectBodyJSON = json
+		h.expectBodyJSONSchema = nil
+		if path != "" {
+			h.expectBodyJSONSchema = &jsonSchema{path: path}
+		}
 	}
 }
 
-// WithExpectBodyXPath configures response xpath expectation
-func WithExpectBodyXPath(xpath string) Option {
+// WithMaxBodySize configures the maximum size of a response body that is read for the body expectations and
+// observations, the body isn't read for the status code and header expectations
+func WithMaxBodySize(size int64) Option {
 	return func(h *HTTP) {
-		h.expectBodyXPath = xpath
+		h.maxBodySize = size
 	}
 }
 
-// WithExpectHeader configures response header expectation
-func WithExpectHeader(header string) Option {
+// WithExpectResponseTime configures the maximum time until the headers of the response
+func WithExpectResponseTime(responseTime time.Duration) Option {
 	return func(h *HTTP) {
-		h.expectHeader = header
+		h.expectResponseTime = responseTime
 	}
 }
 
+// WithExpectResponseTimeConsecutive configures the number of consecutive checks that must respond
+// within the expected response time, the default is one.
+func WithExpectResponseTimeConsecutive(consecutive int) Option {
+	return func(h *HTTP) {
+		h.responseTimeConsecutive = consecutive
+	}
+}
+
+// WithResolve configures the addresses that the hosts and ports resolve to, see ParseResolve
+func WithResolve(rules ...ConnectTo) Option {
+	return func(h *HTTP) {
+		h.resolve = append(h.resolve, rules...)
+	}
+}
+
+// WithConnectTo configures the hosts and ports that are connected to instead, see ParseConnectTo
+func WithConnectTo(rules ...ConnectTo) Option {
+	return func(h *HTTP) {
+		h.connectTo = append(h.connectTo, rules...)
+	}
+}
+
+// appendNonEmpty appends the non-empty values, the empty ones are the unset flags
+func appendNonEmpty(s []string, values []string) []string {
+	for _, value := range values {
+		if value != "" {
+			s = append(s, value)
+		}
+	}
+
+	return s
+}
+
 // WithRequestHeaders configures request header
 func WithRequestHeaders(headers http.Header) Option {
 	return func(h *HTTP) {
@@ -125,10 +248,44 @@ func WithRequestHeaders(headers http.Header) Option {
 	}
 }
 
-// WithRequestBody configures request body
+// WithRequestBody configures request body, it's read once and sent again on every attempt
 func WithRequestBody(body io.Reader) Option {
 	return func(h *HTTP) {
-		h.requestBody = body
+		if body == nil {
+			h.requestBody = nil
+			return
+		}
+
+		var (
+			once    sync.Once
+			content []byte
+			err     error
+		)
+		h.requestBody = func() (io.ReadCloser, error) {
+			once.Do(func() {
+				content, err = io.ReadAll(body)
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			return bytesBody{bytes.NewReader(content)}, nil
+		}
+	}
+}
+
+// WithRequestBodyFunc configures a function that opens the request body of each attempt, e.g. a file.
+// The Content-Length of the request is the size of a regular file, otherwise the body is chunked.
+func WithRequestBodyFunc(open func() (io.ReadCloser, error)) Option {
+	return func(h *HTTP) {
+		h.requestBody = open
+	}
+}
+
+// WithRequestMethod configures request method, it defaults to POST with a request body, otherwise GET
+func WithRequestMethod(method string) Option {
+	return func(h *HTTP) {
+		h.requestMethod = method
 	}
 }
 
@@ -142,10 +299,21 @@ func WithRequestHeader(key string, value []string) Option {
 	}
 }
 
-// WithExpectStatusCode configures response status code expectation
+// WithExpectStatusCode configures response status code expectation, 0 expects none
 func WithExpectStatusCode(code int) Option {
 	return func(h *HTTP) {
-		h.expectStatusCode = code
+		h.expectStatusCode = nil
+		if code != 0 {
+			matcher := StatusCodes(code)
+			h.expectStatusCode = &matcher
+		}
+	}
+}
+
+// WithExpectStatusCodes configures response status code expectation of a matcher, e.g. of ParseStatusCodes
+func WithExpectStatusCodes(matcher StatusCodeMatcher) Option {
+	return func(h *HTTP) {
+		h.expectStatusCode = &matcher
 	}
 }
 
@@ -184,6 +352,14 @@ func WithKeyFile(path string) Option {
 	}
 }
 
+// WithTLSConfig configures the TLS config of HTTPS requests, it takes precedence over
+// WithInsecureSkipTLSVerify, WithCAFile, WithCertFile and WithKeyFile
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(h *HTTP) {
+		h.tlsConfig = tlsConfig
+	}
+}
+
 // WithH2C enables prior-knowledge HTTP/2 over cleartext (h2c) for http:// URLs.
 func WithH2C(enable bool) Option {
 	return func(h *HTTP) {
@@ -191,69 +367,195 @@ func WithH2C(enable bool) Option {
 	}
 }
 
+// WithAllAddresses configures checking every IP address the host resolves to, instead of the first reachable one.
+// The addresses are connected to directly, the proxy of the environment isn't used.
+func WithAllAddresses(allAddresses bool) Option {
+	return func(h *HTTP) {
+		h.allAddresses = allAddresses
+	}
+}
+
+// WithIPFamily restricts the connections to an IP family
+func WithIPFamily(family checker.IPFamily) Option {
+	return func(h *HTTP) {
+		h.ipFamily = family
+	}
+}
+
+// WithDialer configures the dialer of the connections, the proxy of the environment is still used
+func WithDialer(d dialer.DialFunc) Option {
+	return func(h *HTTP) {
+		h.dialer = d
+	}
+}
+
+// WithPersistent keeps the client and its connections between the checks, it's rebuilt after a connection failure.
+// The clients of WithAllAddresses aren't kept.
+func WithPersistent(persistent bool) Option {
+	return func(h *HTTP) {
+		h.persistent = persistent
+	}
+}
+
 // Identity returns the identity of the checker
 func (h *HTTP) Identity() (string, error) {
 	return h.address, nil
 }
 
+// Describe returns the description of the HTTP checker
+func (h *HTTP) Describe() (checker.Description, error) {
+	return checker.DescribeURL("HTTP", h.address)
+}
+
+// ObservationNames returns the names of the observations of the HTTP checker
+func (h *HTTP) ObservationNames() []string {
+	return []string{
+		ObservationStatusCode, ObservationResponseTime, ObservationBody, ObservationJSON,
+		ObservationDNSTime, ObservationConnectTime, ObservationTLSTime, ObservationFirstByteTime, ObservationTotalTime,
+	}
+}
+
 // Check checks HTTP connection
-func (h *HTTP) Check(ctx context.Context) (err error) {
-	tlsConfig, err := h.getTLSConfig()
+func (h *HTTP) Check(ctx context.Context) error {
+	err := h.checkAddresses(ctx)
+	if h.expectResponseTime <= 0 || h.responseTimeConsecutive <= 1 {
+		return err
+	}
+
 	if err != nil {
-		return
+		h.fastResponses.Store(0)
+		return err
 	}
 
-	// Base transport (also used for HTTPS and for HTTP when h2c is not applicable).
-	baseTransport := &http.Transport{
-		TLSClientConfig: tlsConfig,
-		Proxy:           http.ProxyFromEnvironment,
+	if fast := h.fastResponses.Add(1); fast < int64(h.responseTimeConsecutive) {
+		return checker.NewExpectedError(
+			"not enough consecutive responses within the expected response time", nil,
+			"actual", fast, "expect", h.responseTimeConsecutive,
+		)
 	}
 
-	transport := http.RoundTripper(baseTransport)
+	return nil
+}
 
-	// Opt-in h2c (prior-knowledge) for cleartext HTTP when:
-	// - explicitly enabled,
-	// - scheme is http,
-	// - no proxy is configured for this URL,
-	// - noRedirect is true (avoid redirect cross-scheme issues with a single transport).
-	if h.h2c && h.noRedirect {
-		if u, perr := url.Parse(h.address); perr == nil && strings.EqualFold(u.Scheme, "http") {
-			if p, _ := http.ProxyFromEnvironment(&http.Request{URL: u}); p == nil {
-				transport = &http2.Transport{
-					AllowHTTP: true,
-					DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
-						return net.DialTimeout(network, addr, h.timeout)
-					},
-				}
+// checkAddresses makes the HTTP call, to each of the addresses of the host when all of them are checked
+func (h *HTTP) checkAddresses(ctx context.Context) error {
+	if !h.allAddresses || h.unixSocket != "" {
+		return h.check(ctx, nil)
+	}
+
+	u, err := url.Parse(h.requestURL)
+	if err != nil {
+		return err
+	}
+
+	hostAddr := h.overrideAddr(canonicalAddr(u))
+	addresses, err := checker.LookupAddresses(ctx, net.DefaultResolver, hostAddr, h.ipFamily)
+	if err != nil {
+		return err
+	}
+
+	return checker.CheckAll(ctx, addresses, func(ctx context.Context, address string) error {
+		// Only the connections to the host are redirected to the address, so the
+		// Host header, the SNI and the redirects to the other hosts are untouched.
+		return h.check(ctx, func(addr string) string {
+			if addr == hostAddr {
+				return address
 			}
+			return addr
+		})
+	})
+}
+
+// canonicalAddr returns the "host:port" of the URL, with the default port of the scheme when it's missing.
+func canonicalAddr(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if strings.EqualFold(u.Scheme, "https") {
+			port = "443"
 		}
 	}
 
-	httpClient := &http.Client{
-		Timeout:   h.timeout,
-		Transport: transport,
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// check makes the HTTP call, the dialed addresses are rewritten by the resolve function when it's not nil.
+func (h *HTTP) check(ctx context.Context, resolve func(addr string) string) (err error) {
+	client := h.client
+	if resolve != nil {
+		// The dials of each address differ, so their clients aren't kept.
+		client = checker.NewPersistentClient(false, closeClient)
 	}
 
-	if h.noRedirect {
-		httpClient.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
-			return http.ErrUseLastResponse
+	httpClient, err := client.Get(func() (*http.Client, error) {
+		return h.newClient(resolve)
+	})
+	if err != nil {
+		return err
+	}
+
+	broken := false
+	defer func() {
+		_ = client.Release(httpClient, broken)
+	}()
+
+	method := h.requestMethod
+	if method == "" {
+		method = http.MethodGet
+		if h.requestBody != nil {
+			method = http.MethodPost
 		}
 	}
 
-	method := http.MethodGet
+	var body io.ReadCloser
 	if h.requestBody != nil {
-		method = http.MethodPost
+		body, err = h.requestBody()
+		if err != nil {
+			return fmt.Errorf("can't open the request body: %w", err)
+		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, h.address, h.requestBody)
+	ctx, timings := withTimings(ctx)
+	req, err := http.NewRequestWithContext(ctx, method, h.requestURL, body)
 	if err != nil {
+		if body != nil {
+			body.Close()
+		}
+		return err
+	}
+
+	if body != nil {
+		req.ContentLength = requestBodyLength(body)
+		if req.ContentLength == 0 {
+			// A non-nil body of zero length would be chunked.
+			body.Close()
+			req.Body = http.NoBody
+		}
+
+		// The body is sent again on the redirects that keep it, e.g. 307 and 308.
+		req.GetBody = h.requestBody
+	}
+
+	req.Header = h.requestHeaders.Clone()
+	if req.Header == nil {
+		req.Header = make(http.Header)
+	}
+
+	if err := h.authenticate(req); err != nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
 		return err
 	}
 
-	req.Header = h.requestHeaders
+	timings.begin()
+	defer timings.observe(ctx)
 
 	resp, err := httpClient.Do(req)
 	if err != nil {
+		// Only a connection-level failure rebuilds the client, not e.g. a certificate or a token error.
+		broken = checker.IsConnectionError(err)
+
 		if os.IsTimeout(err) {
 			return checker.NewExpectedError(
 				"timed out while making an http call", err,
@@ -269,167 +571,354 @@ func (h *HTTP) Check(ctx context.Context) (err error) {
 		return err
 	}
 
+	checker.Observe(ctx, ObservationStatusCode, resp.StatusCode)
+	responseTime := timings.since()
+	checker.Observe(ctx, ObservationResponseTime, responseTime)
+
 	defer func(body io.ReadCloser) {
+		if h.persistent {
+			// The connection is only reused once its body is read.
+			_, _ = io.Copy(io.Discard, io.LimitReader(body, drainLimit))
+		}
+
 		if cerr := body.Close(); cerr != nil {
 			err = cerr
 		}
 	}(resp.Body)
 
-	if h.expectStatusCode != 0 {
+	if h.expectResponseTime > 0 && responseTime > h.expectResponseTime {
+		return checker.NewExpectedError(
+			"the response time is longer than expected", nil,
+			"actual", responseTime, "expect", h.expectResponseTime,
+		)
+	}
+
+	if h.expectStatusCode != nil {
 		err := h.checkingStatusCodeExpectation(resp)
 		if err != nil {
 			return err
 		}
 	}
 
-	if h.expectBodyRegex != "" {
-		err := h.checkingBodyExpectation(resp)
-		if err != nil {
+	for _, header := range h.expectHeader {
+		if err := h.checkingHeaderExpectation(resp, header); err != nil {
 			return err
 		}
 	}
 
-	if h.expectBodyJSON != "" {
-		err := h.checkingJSONExpectation(resp)
-		if err != nil {
+	observeBody := checker.ObservationRequested(ctx, ObservationBody) || checker.ObservationRequested(ctx, ObservationJSON)
+	if !observeBody && len(h.expectBodyRegex)+len(h.expectBodyJSON)+len(h.expectBodyXPath) == 0 && h.expectBodyJSONSchema == nil {
+		return nil
+	}
+
+	// The body is read once, and shared by the observations and the expectations.
+	respBody, err := h.readBody(resp)
+	if err != nil {
+		return err
+	}
+
+	if observeBody {
+		checker.Observe(ctx, ObservationBody, respBody)
+
+		var decoded any
+		if json.Unmarshal([]byte(respBody), &decoded) == nil {
+			checker.Observe(ctx, ObservationJSON, decoded)
+		}
+	}
+
+	for _, regex := range h.expectBodyRegex {
+		if err := h.checkingBodyExpectation(respBody, regex); err != nil {
 			return err
 		}
 	}
 
-	if h.expectBodyXPath != "" {
-		err := h.checkingXPathExpectation(resp)
-		if err != nil {
+	for _, expectation := range h.expectBodyJSON {
+		if err := h.checkingJSONExpectation(respBody, expectation); err != nil {
+			return err
+		}
+	}
+
+	for _, xpath := range h.expectBodyXPath {
+		if err := h.checkingXPathExpectation(respBody, xpath); err != nil {
 			return err
 		}
 	}
 
-	if h.expectHeader != "" {
-		return h.checkingHeaderExpectation(resp)
+	if h.expectBodyJSONSchema != nil {
+		return h.checkingJSONSchemaExpectation(respBody)
 	}
 
 	return nil
 }
 
-// getTLSConfig prepares TLS config
-func (h *HTTP) getTLSConfig() (*tls.Config, error) {
-	cfg := tls.Config{
-		InsecureSkipVerify: h.insecureSkipTLSVerify,
+// readBody reads the body of the response, up to the maximum body size
+func (h *HTTP) readBody(resp *http.Response) (string, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, h.maxBodySize+1))
+	if err != nil {
+		return "", err
 	}
 
-	if h.insecureSkipTLSVerify {
-		return &cfg, nil
+	if int64(len(body)) > h.maxBodySize {
+		return "", checker.NewExpectedError(
+			"the body is larger than the maximum body size", nil,
+			"max_body_size", h.maxBodySize,
+		)
 	}
 
-	// Cert and key files.
-	if h.certFile != "" || h.keyFile != "" {
-		cert, err := tls.LoadX509KeyPair(h.certFile, h.keyFile)
-		if err != nil {
-			return nil, err
+	return string(body), nil
+}
+
+// bytesBody is a request body of a buffer, its length is known
+type bytesBody struct {
+	*bytes.Reader
+}
+
+// Close closes the body, there's nothing to release
+func (bytesBody) Close() error {
+	return nil
+}
+
+// requestBodyLength returns the length of a request body of a buffer or a regular file, otherwise -1 for unknown
+func requestBodyLength(body io.ReadCloser) int64 {
+	switch body := body.(type) {
+	case bytesBody:
+		return int64(body.Len())
+	case interface{ Stat() (os.FileInfo, error) }:
+		if info, err := body.Stat(); err == nil && info.Mode().IsRegular() {
+			return info.Size()
 		}
-		cfg.Certificates = []tls.Certificate{cert}
 	}
 
-	// CA file.
-	if h.caFile != "" {
-		ca, err := os.ReadFile(h.caFile)
+	return -1
+}
+
+// Close closes the connections of the persistent mode
+func (h *HTTP) Close() error {
+	if h.tokenClient != nil {
+		h.tokenClient.CloseIdleConnections()
+	}
+
+	return h.client.Close()
+}
+
+// newClient creates the HTTP client, its dialed addresses are rewritten by the resolve function when it's not nil.
+func (h *HTTP) newClient(resolve func(addr string) string) (*http.Client, error) {
+	tlsConfig, err := h.getTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := h.proxy
+	if h.unixSocket != "" {
+		// The connections don't leave the host.
+		proxy = nil
+	} else if resolve != nil {
+		// A proxy would be dialed instead of each of the addresses, and check only itself.
+		proxy = nil
+	}
+
+	dialContext := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if h.unixSocket != "" {
+			return dialer.WithTimeout(h.dialer, h.timeout)(ctx, "unix", h.unixSocket)
+		}
+
+		addr = h.overrideAddr(addr)
+		if resolve != nil {
+			addr = resolve(addr)
+		}
+
+		return dialer.WithTimeout(h.dialer, h.timeout)(ctx, h.ipFamily.Network(network), addr)
+	}
+
+	// Base transport (also used for HTTPS and for HTTP when h2c is not applicable).
+	baseTransport := &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy:           proxy,
+		DialContext:     dialContext,
+	}
+
+	transport := http.RoundTripper(baseTransport)
+
+	if h.http3 {
+		transport, err = h.newHTTP3Transport(tlsConfig, resolve)
 		if err != nil {
 			return nil, err
 		}
-		certPool := x509.NewCertPool()
-		if !certPool.AppendCertsFromPEM(ca) {
-			return nil, errors.New("can't append the CA file")
+	}
+
+	// Opt-in h2c (prior-knowledge) for cleartext HTTP when:
+	// - explicitly enabled,
+	// - scheme is http,
+	// - no proxy is configured for this URL,
+	// - noRedirect is true (avoid redirect cross-scheme issues with a single transport).
+	if h.h2c && h.noRedirect && !h.http3 {
+		if u, perr := url.Parse(h.requestURL); perr == nil && strings.EqualFold(u.Scheme, "http") {
+			var p *url.URL
+			if proxy != nil {
+				p, _ = proxy(&http.Request{URL: u})
+			}
+			if p == nil {
+				transport = &http2.Transport{
+					AllowHTTP: true,
+					DialTLSContext: func(ctx context.Context, network, addr string, _ *tls.Config) (net.Conn, error) {
+						return dialContext(ctx, network, addr)
+					},
+				}
+			}
+		}
+	}
+
+	httpClient := &http.Client{
+		Timeout:   h.timeout,
+		Transport: transport,
+	}
+
+	if h.noRedirect {
+		httpClient.CheckRedirect = func(_ *http.Request, _ []*http.Request) error {
+			return http.ErrUseLastResponse
 		}
-		cfg.RootCAs = certPool
 	}
 
-	return &cfg, nil
+	return httpClient, nil
+}
+
+// closeClient closes the idle connections of the client
+func closeClient(c *http.Client) error {
+	c.CloseIdleConnections()
+	return nil
+}
+
+// getTLSConfig prepares TLS config
+func (h *HTTP) getTLSConfig() (*tls.Config, error) {
+	if h.tlsConfig != nil {
+		return h.tlsConfig.Clone(), nil
+	}
+
+	if h.insecureSkipTLSVerify {
+		return &tls.Config{InsecureSkipVerify: true}, nil
+	}
+
+	return tlsconfig.New(
+		tlsconfig.WithCAFile(h.caFile),
+		tlsconfig.WithCertFile(h.certFile),
+		tlsconfig.WithKeyFile(h.keyFile),
+	)
 }
 
 func (h *HTTP) checkingStatusCodeExpectation(resp *http.Response) error {
-	if h.expectStatusCode != resp.StatusCode {
+	if !h.expectStatusCode.Match(resp.StatusCode) {
 		return checker.NewExpectedError(
 			"the status code doesn't expect", nil,
-			"actual", resp.StatusCode, "expect", h.expectStatusCode,
+			"actual", resp.StatusCode, "expect", h.expectStatusCode.String(),
 		)
 	}
 
 	return nil
 }
 
-func (h *HTTP) checkingBodyExpectation(resp *http.Response) error {
-	bodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
-
-	bodyString := string(bodyBytes)
-	matched, _ := regexp.MatchString(h.expectBodyRegex, bodyString)
+func (h *HTTP) checkingBodyExpectation(body, regex string) error {
+	matched, _ := regexp.MatchString(regex, body)
 
 	if !matched {
 		return checker.NewExpectedError(
 			"the body doesn't expect", nil,
-			"actual", h.truncateString(bodyString, 50), "expect", h.expectBodyRegex,
+			"actual", h.truncateString(body, 50), "expect", regex,
 		)
 	}
 
 	return nil
 }
 
-func (h *HTTP) checkingJSONExpectation(resp *http.Response) error {
-	bodyBytes, err := io.ReadAll(resp.Body)
+func (h *HTTP) checkingJSONExpectation(body, expectation string) error {
+	e, err := parseJSONExpectation(expectation)
 	if err != nil {
 		return err
 	}
 
-	bodyString := string(bodyBytes)
-	value := gjson.Get(bodyString, h.expectBodyJSON)
+	value := gjson.Get(body, e.path)
 
 	if !value.Exists() {
 		return checker.NewExpectedError(
 			"the JSON doesn't match", nil,
-			"actual", h.truncateString(bodyString, 50), "expect", h.expectBodyJSON,
+			"actual", h.truncateString(body, 50), "expect", expectation,
+		)
+	}
+
+	matched, err := e.match(value)
+	if err != nil {
+		return checker.NewExpectedError(
+			"the JSON value can't be compared", err,
+			"actual", value.Raw, "expect", expectation,
+		)
+	}
+
+	if !matched {
+		return checker.NewExpectedError(
+			"the JSON value doesn't match", nil,
+			"actual", value.Raw, "expect", expectation,
 		)
 	}
 
 	return nil
 }
 
-func (h *HTTP) checkingXPathExpectation(resp *http.Response) error {
-	bodyBytes, err := io.ReadAll(resp.Body)
+func (h *HTTP) checkingJSONSchemaExpectation(body string) error {
+	schema, err := h.expectBodyJSONSchema.compile()
+	if err != nil {
+		return err
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(strings.NewReader(body))
 	if err != nil {
+		return checker.NewExpectedError(
+			"the body isn't JSON", err,
+			"actual", h.truncateString(body, 50),
+		)
+	}
+
+	err = schema.Validate(instance)
+
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
 		return err
 	}
 
-	bodyString := string(bodyBytes)
-	doc, err := htmlquery.Parse(strings.NewReader(bodyString))
+	return checker.NewExpectedError(
+		"the body doesn't validate against the JSON schema", nil,
+		"schema", h.expectBodyJSONSchema.path, "errors", validationErrors(validationErr),
+	)
+}
+
+func (h *HTTP) checkingXPathExpectation(body, xpath string) error {
+	doc, err := htmlquery.Parse(strings.NewReader(body))
 	if err != nil {
 		return err
 	}
 
-	node, err := htmlquery.Query(doc, h.expectBodyXPath)
+	node, err := htmlquery.Query(doc, xpath)
 	if err != nil {
 		return err
 	}
 	if node == nil {
 		return checker.NewExpectedError(
 			"the XPath doesn't match", nil,
-			"actual", h.truncateString(bodyString, 50), "expect", h.expectBodyXPath,
+			"actual", h.truncateString(body, 50), "expect", xpath,
 		)
 	}
 
 	return nil
 }
 
-func (h *HTTP) checkingHeaderExpectation(resp *http.Response) error {
+func (h *HTTP) checkingHeaderExpectation(resp *http.Response, expectHeader string) error {
 	// Key value. e.g. Content-Type=application/json
-	expectedHeaderParsed := strings.SplitN(h.expectHeader, "=", 2)
+	expectedHeaderParsed := strings.SplitN(expectHeader, "=", 2)
 	if len(expectedHeaderParsed) == 2 {
 		headerValue := resp.Header.Get(expectedHeaderParsed[0])
 		matched, _ := regexp.MatchString(expectedHeaderParsed[1], headerValue)
 		if !matched {
 			return checker.NewExpectedError(
 				"the http header key and value doesn't expect", nil,
-				"actual", headerValue, "expect", h.expectHeader,
+				"actual", headerValue, "expect", expectHeader,
 			)
 		}
 	}
@@ -438,7 +927,7 @@ func (h *HTTP) checkingHeaderExpectation(resp *http.Response) error {
 	if _, ok := resp.Header[expectedHeaderParsed[0]]; !ok {
 		return checker.NewExpectedError(
 			"the http header key doesn't expect", nil,
-			"actual", resp.Header, "expect", h.expectHeader,
+			"actual", resp.Header, "expect", expectHeader,
 		)
 	}
 
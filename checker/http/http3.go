@@ -0,0 +1,96 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http/httptrace"
+
+	"github.com/quic-go/quic-go"
+	"github.com/quic-go/quic-go/http3"
+	"wait4x.dev/v3/checker"
+)
+
+// ErrHTTP3Dialer defines the error of a custom dialer of the HTTP/3 connections, that are over UDP
+var ErrHTTP3Dialer = errors.New("HTTP/3 doesn't support a custom dialer")
+
+// WithHTTP3 configures the HTTP/3 transport over QUIC, instead of the TCP one, for https:// URLs
+func WithHTTP3(http3 bool) Option {
+	return func(h *HTTP) {
+		h.http3 = http3
+	}
+}
+
+// newHTTP3Transport returns the HTTP/3 transport, the dialed addresses are rewritten by the
+// resolve function when it's not nil.
+func (h *HTTP) newHTTP3Transport(tlsConfig *tls.Config, resolve func(addr string) string) (*http3.Transport, error) {
+	if h.dialer != nil {
+		return nil, ErrHTTP3Dialer
+	}
+
+	return &http3.Transport{
+		TLSClientConfig: tlsConfig,
+		QUICConfig:      &quic.Config{HandshakeIdleTimeout: h.timeout},
+		Dial: func(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (*quic.Conn, error) {
+			addr = h.overrideAddr(addr)
+			if resolve != nil {
+				addr = resolve(addr)
+			}
+
+			return h.dialQUIC(ctx, addr, tlsCfg, cfg)
+		},
+	}, nil
+}
+
+// dialQUIC dials a QUIC connection to the first address of the host in the IP family. The errors
+// before the handshake are dial errors, so they're classified like the TCP ones.
+func (h *HTTP) dialQUIC(ctx context.Context, addr string, tlsCfg *tls.Config, cfg *quic.Config) (*quic.Conn, error) {
+	addresses, err := checker.LookupAddresses(ctx, net.DefaultResolver, addr, h.ipFamily)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: "udp", Err: err}
+	}
+	address := addresses[0]
+
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace != nil && trace.ConnectStart != nil {
+		trace.ConnectStart("udp", address)
+	}
+	if trace != nil && trace.TLSHandshakeStart != nil {
+		trace.TLSHandshakeStart()
+	}
+
+	conn, err := quic.DialAddrEarly(ctx, address, tlsCfg, cfg)
+
+	var state tls.ConnectionState
+	if conn != nil {
+		state = conn.ConnectionState().TLS
+	}
+	if trace != nil && trace.TLSHandshakeDone != nil {
+		trace.TLSHandshakeDone(state, err)
+	}
+	if trace != nil && trace.ConnectDone != nil {
+		trace.ConnectDone("udp", address, err)
+	}
+
+	var transportErr *quic.TransportError
+	if err != nil && !errors.As(err, &transportErr) {
+		return nil, &net.OpError{Op: "dial", Net: "udp", Err: err}
+	}
+
+	return conn, err
+}
@@ -0,0 +1,112 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/quic-go/quic-go/http3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wait4x.dev/v3/checker"
+)
+
+// http3Server returns the URL of an HTTP/3 server of the handler, and the TLS config that trusts it
+func http3Server(t *testing.T, handler http.Handler) (string, *tls.Config) {
+	// The certificate of the TLS test server is reused for the QUIC listener.
+	ts := httptest.NewTLSServer(http.NotFoundHandler())
+	t.Cleanup(ts.Close)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &http3.Server{
+		Handler:   handler,
+		TLSConfig: http3.ConfigureTLSConfig(&tls.Config{Certificates: ts.TLS.Certificates}),
+	}
+	go func() { _ = server.Serve(conn) }()
+	t.Cleanup(func() {
+		_ = server.Close()
+		_ = conn.Close()
+	})
+
+	return "https://" + conn.LocalAddr().String(), ts.Client().Transport.(*http.Transport).TLSClientConfig
+}
+
+// TestHttpHTTP3 tests the expectations and the timings of the requests over HTTP/3
+func TestHttpHTTP3(t *testing.T) {
+	var proto string
+	address, tlsConfig := http3Server(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proto = r.Proto
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "UP"}`))
+	}))
+
+	hc := New(address+"/health",
+		WithHTTP3(true),
+		WithTLSConfig(tlsConfig),
+		WithExpectStatusCode(http.StatusOK),
+		WithExpectHeader("Content-Type=application/json"),
+		WithExpectBodyJSON(`status == "UP"`),
+	)
+
+	ctx, observations := checker.WithObservations(context.Background())
+	assert.NoError(t, hc.Check(ctx))
+	assert.Equal(t, "HTTP/3.0", proto)
+
+	for _, name := range []string{ObservationConnectTime, ObservationTLSTime, ObservationFirstByteTime} {
+		_, ok := checker.ObservationValue[time.Duration](observations(), name)
+		assert.True(t, ok, name)
+	}
+
+	// The TLS options are reused, so an untrusted certificate fails.
+	err := New(address, WithHTTP3(true), WithTLSConfig(&tls.Config{})).Check(context.Background())
+	assert.Error(t, err)
+
+	_, port, err := net.SplitHostPort(address[len("https://"):])
+	require.NoError(t, err)
+	resolve, err := ParseResolve("example.com:" + port + ":127.0.0.1")
+	require.NoError(t, err)
+	hc = New("https://example.com:"+port, WithHTTP3(true), WithTLSConfig(tlsConfig), WithResolve(resolve))
+	assert.NoError(t, hc.Check(context.Background()))
+}
+
+// TestHttpHTTP3Failures tests that the HTTP/3 failures are classified like the TCP ones
+func TestHttpHTTP3Failures(t *testing.T) {
+	// Nothing listens on the UDP port of the closed connection.
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := "https://" + conn.LocalAddr().String()
+	require.NoError(t, conn.Close())
+
+	var expectedError *checker.ExpectedError
+	err = New(address, WithHTTP3(true), WithTimeout(300*time.Millisecond)).Check(context.Background())
+	assert.True(t, errors.As(err, &expectedError), err)
+
+	err = New("https://wait4x.invalid", WithHTTP3(true), WithTimeout(300*time.Millisecond)).Check(context.Background())
+	assert.True(t, errors.As(err, &expectedError), err)
+
+	err = New(address, WithHTTP3(true), WithDialer(func(context.Context, string, string) (net.Conn, error) {
+		return nil, errors.New("unused")
+	})).Check(context.Background())
+	assert.ErrorIs(t, err, ErrHTTP3Dialer)
+}
@@ -16,13 +16,21 @@
 package http
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -30,6 +38,7 @@ import (
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 // TestMain is the main function for the HTTP checker.
@@ -58,6 +67,202 @@ func TestHttpValidAddress(t *testing.T) {
 	assert.Equal(t, ts.URL, identity)
 }
 
+// TestHttpObservations tests the observations of the HTTP checker.
+func TestHttpObservations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer ts.Close()
+
+	ctx, observations := checker.WithObservations(context.Background())
+	assert.Error(t, New(ts.URL, WithExpectStatusCode(http.StatusOK)).Check(ctx))
+
+	statusCode, ok := checker.ObservationValue[int](observations(), ObservationStatusCode)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusTeapot, statusCode)
+
+	responseTime, ok := checker.ObservationValue[time.Duration](observations(), ObservationResponseTime)
+	assert.True(t, ok)
+	assert.Positive(t, responseTime)
+}
+
+// TestHttpObservationsBody tests that the HTTP checker observes the body when it's requested.
+func TestHttpObservationsBody(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"version": "2.4.1"}`))
+	}))
+	defer ts.Close()
+
+	hc := New(ts.URL, WithExpectBodyJSON("version"))
+
+	ctx, observations := checker.WithObservations(context.Background())
+	assert.NoError(t, hc.Check(ctx))
+	_, ok := observations().Lookup(ObservationBody)
+	assert.False(t, ok)
+
+	ctx, observations = checker.WithObservations(checker.RequestObservations(context.Background(), ObservationJSON))
+	assert.NoError(t, hc.Check(ctx))
+
+	body, ok := checker.ObservationValue[string](observations(), ObservationBody)
+	assert.True(t, ok)
+	assert.JSONEq(t, `{"version": "2.4.1"}`, body)
+
+	decoded, ok := checker.ObservationValue[map[string]any](observations(), ObservationJSON)
+	assert.True(t, ok)
+	assert.Equal(t, "2.4.1", decoded["version"])
+}
+
+// TestHttpRequestBodyRetries tests that the request body is sent again on every attempt, with its length.
+func TestHttpRequestBodyRetries(t *testing.T) {
+	var methods, bodies []string
+	var lengths []int64
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		methods = append(methods, r.Method)
+		bodies = append(bodies, string(body))
+		lengths = append(lengths, r.ContentLength)
+	}))
+	defer ts.Close()
+
+	hc := New(ts.URL, WithRequestBody(strings.NewReader(`{"key": "value"}`)))
+	for range 3 {
+		assert.NoError(t, hc.Check(context.Background()))
+	}
+	assert.Equal(t, []string{http.MethodPost, http.MethodPost, http.MethodPost}, methods)
+	assert.Equal(t, []string{`{"key": "value"}`, `{"key": "value"}`, `{"key": "value"}`}, bodies)
+	assert.Equal(t, []int64{16, 16, 16}, lengths)
+
+	methods, bodies, lengths = nil, nil, nil
+	path := filepath.Join(t.TempDir(), "body.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"file": true}`), 0o600))
+	hc = New(ts.URL, WithRequestMethod(http.MethodPut), WithRequestBodyFunc(func() (io.ReadCloser, error) {
+		return os.Open(path)
+	}))
+	for range 2 {
+		assert.NoError(t, hc.Check(context.Background()))
+	}
+	assert.Equal(t, []string{http.MethodPut, http.MethodPut}, methods)
+	assert.Equal(t, []string{`{"file": true}`, `{"file": true}`}, bodies)
+	assert.Equal(t, []int64{14, 14}, lengths)
+
+	// The length of the other bodies is unknown, so they're chunked.
+	methods, bodies, lengths = nil, nil, nil
+	var opened int
+	hc = New(ts.URL, WithRequestMethod(http.MethodPut), WithRequestBodyFunc(func() (io.ReadCloser, error) {
+		opened++
+		return io.NopCloser(strings.NewReader(fmt.Sprintf("attempt %d", opened))), nil
+	}))
+	for range 2 {
+		assert.NoError(t, hc.Check(context.Background()))
+	}
+	assert.Equal(t, []string{http.MethodPut, http.MethodPut}, methods)
+	assert.Equal(t, []string{"attempt 1", "attempt 2"}, bodies)
+	assert.Equal(t, []int64{-1, -1}, lengths)
+
+	lengths = nil
+	hc = New(ts.URL, WithRequestBody(strings.NewReader("")))
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.Equal(t, []int64{0}, lengths)
+
+	errOpen := errors.New("open error")
+	hc = New(ts.URL, WithRequestBodyFunc(func() (io.ReadCloser, error) { return nil, errOpen }))
+	assert.ErrorIs(t, hc.Check(context.Background()), errOpen)
+}
+
+// TestHttpRequestBodyRedirect tests that the request body is sent again with its length on a redirect that keeps it.
+func TestHttpRequestBodyRedirect(t *testing.T) {
+	var bodies []string
+	var lengths []int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		lengths = append(lengths, r.ContentLength)
+		if r.URL.Path == "/" {
+			http.Redirect(w, r, "/moved", http.StatusTemporaryRedirect)
+		}
+	}))
+	defer ts.Close()
+
+	hc := New(ts.URL, WithRequestBody(strings.NewReader("hello")), WithExpectStatusCode(http.StatusOK))
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.Equal(t, []string{"hello", "hello"}, bodies)
+	assert.Equal(t, []int64{5, 5}, lengths)
+}
+
+// TestHttpRequestMethod tests the request method of the HTTP checker.
+func TestHttpRequestMethod(t *testing.T) {
+	var method string
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		method = r.Method
+	}))
+	defer ts.Close()
+
+	assert.NoError(t, New(ts.URL).Check(context.Background()))
+	assert.Equal(t, http.MethodGet, method)
+
+	for _, m := range []string{http.MethodHead, http.MethodOptions, http.MethodDelete, http.MethodPatch} {
+		assert.NoError(t, New(ts.URL, WithRequestMethod(m)).Check(context.Background()))
+		assert.Equal(t, m, method)
+	}
+}
+
+// TestHttpSeveralBodyExpectations tests that all of the body expectations match the same body.
+func TestHttpSeveralBodyExpectations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("X-Version", "2.4.1")
+		_, _ = w.Write([]byte(`<html><body><div id="status">{"status": "healthy", "version": "2.4.1"}</div></body></html>`))
+	}))
+	defer ts.Close()
+
+	hc := New(ts.URL,
+		WithExpectBodyRegex(`healthy`, `version`),
+		WithExpectBodyXPath(`//div[@id="status"]`),
+		WithExpectHeader("Content-Type=text/html", "X-Version=^2\\."),
+	)
+	assert.NoError(t, hc.Check(context.Background()))
+
+	hc = New(ts.URL, WithExpectBodyRegex(`healthy`), WithExpectBodyRegex(`degraded`))
+	var expectedError *checker.ExpectedError
+	assert.ErrorAs(t, hc.Check(context.Background()), &expectedError)
+	assert.Equal(t, "the body doesn't expect", expectedError.Error())
+
+	hc = New(ts.URL, WithExpectHeader("Content-Type", "X-Missing"))
+	assert.ErrorAs(t, hc.Check(context.Background()), &expectedError)
+}
+
+// TestHttpMaxBodySize tests the maximum size of the body that is read for the expectations.
+func TestHttpMaxBodySize(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "healthy"}`))
+	}))
+	defer ts.Close()
+
+	assert.NoError(t, New(ts.URL, WithExpectBodyJSON("status"), WithMaxBodySize(21)).Check(context.Background()))
+
+	var expectedError *checker.ExpectedError
+	assert.ErrorAs(t, New(ts.URL, WithExpectBodyJSON("status"), WithMaxBodySize(20)).Check(context.Background()), &expectedError)
+	assert.Equal(t, "the body is larger than the maximum body size", expectedError.Error())
+}
+
+// TestHttpLargeBodyWithoutBodyExpectations tests that a body over the maximum body size doesn't fail the checks
+// that don't need it.
+func TestHttpLargeBodyWithoutBodyExpectations(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(make([]byte, 2*DefaultMaxBodySize))
+	}))
+	defer ts.Close()
+
+	for _, persistent := range []bool{false, true} {
+		hc := New(ts.URL, WithExpectStatusCode(http.StatusOK), WithExpectHeader("Content-Type=application/octet-stream"), WithPersistent(persistent))
+		for range 2 {
+			assert.NoError(t, hc.Check(context.Background()))
+		}
+		assert.NoError(t, hc.(*HTTP).Close())
+	}
+}
+
 // TestHttpInvalidStatusCode tests the HTTP checker with an invalid status code.
 func TestHttpInvalidStatusCode(t *testing.T) {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
@@ -366,3 +571,160 @@ func TestHttpRequestHeaderWithoutInit(t *testing.T) {
 	)
 	assert.Nil(t, hc.Check(context.TODO()))
 }
+
+// TestHttpAllAddresses tests the HTTP checker with checking every address of the host.
+func TestHttpAllAddresses(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	defer ts.Close()
+
+	u, err := url.Parse(ts.URL)
+	assert.NoError(t, err)
+	host := "localhost:" + u.Port()
+
+	hc := New(
+		"http://"+host,
+		WithAllAddresses(true),
+		WithIPFamily(checker.IPFamilyIPv4),
+		WithExpectBodyRegex("^"+host+"$"),
+	)
+	assert.Nil(t, hc.Check(context.TODO()))
+}
+
+// TestHttpAllAddressesProxy tests that the HTTP checker connects to every address directly, not to the proxy.
+func TestHttpAllAddressesProxy(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		proxied.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer proxy.Close()
+
+	proxyURL, err := url.Parse(proxy.URL)
+	assert.NoError(t, err)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	address := ts.URL
+	ts.Close()
+
+	hc := New(address, WithTimeout(time.Second))
+	hc.(*HTTP).proxy = http.ProxyURL(proxyURL)
+	assert.NoError(t, hc.Check(context.TODO()))
+	assert.Equal(t, int32(1), proxied.Load())
+
+	hc = New(address, WithAllAddresses(true), WithTimeout(time.Second))
+	hc.(*HTTP).proxy = http.ProxyURL(proxyURL)
+	assert.ErrorContains(t, hc.Check(context.TODO()), "1 of 1 addresses aren't ready")
+	assert.Equal(t, int32(1), proxied.Load())
+}
+
+// TestHttpAllAddressesFailed tests the HTTP checker with an unreachable address of the host.
+func TestHttpAllAddressesFailed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	address := ts.URL
+	ts.Close()
+
+	hc := New(address, WithAllAddresses(true), WithTimeout(time.Second))
+
+	var expectedError *checker.ExpectedError
+	err := hc.Check(context.TODO())
+	assert.ErrorAs(t, err, &expectedError)
+	assert.Contains(t, err.Error(), "1 of 1 addresses aren't ready")
+}
+
+// TestHttpTLSConfig tests the HTTP checker with a custom TLS config.
+func TestHttpTLSConfig(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	hc := New(ts.URL, WithTLSConfig(ts.Client().Transport.(*http.Transport).TLSClientConfig))
+	assert.Nil(t, hc.Check(context.TODO()))
+
+	hc = New(ts.URL, WithTLSConfig(&tls.Config{}))
+	assert.ErrorContains(t, hc.Check(context.TODO()), "certificate")
+}
+
+// TestHttpWithDialer tests the HTTP checker over an in-memory connection of a custom dialer.
+func TestHttpWithDialer(t *testing.T) {
+	var dialed string
+	hc := New("http://in-memory/health", WithDialer(func(_ context.Context, network, address string) (net.Conn, error) {
+		dialed = network + "/" + address
+		client, server := net.Pipe()
+		go func() {
+			defer server.Close()
+			req, err := http.ReadRequest(bufio.NewReader(server))
+			if err != nil {
+				return
+			}
+			status := http.StatusNotFound
+			if req.URL.Path == "/health" {
+				status = http.StatusOK
+			}
+			fmt.Fprintf(server, "HTTP/1.1 %d %s\r\nContent-Length: 0\r\nConnection: close\r\n\r\n", status, http.StatusText(status))
+		}()
+		return client, nil
+	}))
+
+	assert.Nil(t, hc.Check(context.TODO()))
+	assert.Equal(t, "tcp/in-memory:80", dialed)
+}
+
+// TestHttpPersistent tests that the persistent mode reuses the connection between the checks.
+func TestHttpPersistent(t *testing.T) {
+	var newConns, closedConns atomic.Int32
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "ok")
+	}))
+	ts.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		switch state {
+		case http.StateNew:
+			newConns.Add(1)
+		case http.StateClosed:
+			closedConns.Add(1)
+		}
+	}
+	ts.Start()
+	defer ts.Close()
+
+	hc := New(ts.URL, WithExpectStatusCode(http.StatusOK), WithPersistent(true))
+	for range 3 {
+		assert.Nil(t, hc.Check(context.TODO()))
+	}
+	assert.Equal(t, int32(1), newConns.Load())
+
+	assert.NoError(t, hc.(*HTTP).Close())
+	assert.Eventually(t, func() bool { return closedConns.Load() == 1 }, time.Second, 10*time.Millisecond)
+
+	hc = New(ts.URL, WithExpectStatusCode(http.StatusOK))
+	for range 3 {
+		assert.Nil(t, hc.Check(context.TODO()))
+	}
+	assert.Equal(t, int32(4), newConns.Load())
+}
+
+// TestHttpNoFDLeak tests that the checks release their connections.
+func TestHttpNoFDLeak(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	hc := New(ts.URL, WithExpectStatusCode(http.StatusOK))
+	test.AssertFDsBounded(t, 200, 10, func() {
+		assert.Nil(t, hc.Check(context.TODO()))
+	})
+
+	hc = New(ts.URL, WithExpectStatusCode(http.StatusOK), WithPersistent(true))
+	test.AssertFDsBounded(t, 200, 10, func() {
+		assert.Nil(t, hc.Check(context.TODO()))
+	})
+	assert.NoError(t, hc.(*HTTP).Close())
+}
@@ -0,0 +1,206 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// The operators of the JSON expectations
+const (
+	jsonOpExists   = ""
+	jsonOpEqual    = "=="
+	jsonOpNotEqual = "!="
+	jsonOpLess     = "<"
+	jsonOpLessEq   = "<="
+	jsonOpGreater  = ">"
+	jsonOpGreatEq  = ">="
+	jsonOpMatch    = "=~"
+	jsonOpIn       = "in"
+	jsonOpIs       = "is"
+)
+
+// jsonOps are the operators of the JSON expectations, the longer ones first
+var jsonOps = []string{
+	jsonOpEqual, jsonOpNotEqual, jsonOpLessEq, jsonOpGreatEq, jsonOpMatch,
+	jsonOpLess, jsonOpGreater, jsonOpIn, jsonOpIs,
+}
+
+// jsonTypes are the types of the "is" operator
+var jsonTypes = map[string]func(gjson.Result) bool{
+	"string":  func(r gjson.Result) bool { return r.Type == gjson.String },
+	"number":  func(r gjson.Result) bool { return r.Type == gjson.Number },
+	"bool":    func(r gjson.Result) bool { return r.IsBool() },
+	"boolean": func(r gjson.Result) bool { return r.IsBool() },
+	"object":  gjson.Result.IsObject,
+	"array":   gjson.Result.IsArray,
+	"null":    func(r gjson.Result) bool { return r.Type == gjson.Null },
+}
+
+// ErrInvalidJSONExpectation defines the error of an invalid JSON expectation
+var ErrInvalidJSONExpectation = errors.New("invalid JSON expectation")
+
+// jsonExpectation is an expectation of the value at a GJSON path, e.g. `status == "UP"` or `replicas_ready >= 3`
+type jsonExpectation struct {
+	expectation string
+	path        string
+	op          string
+	values      []gjson.Result
+	regex       *regexp.Regexp
+}
+
+// ValidateExpectBodyJSON validates a JSON expectation: a GJSON path, that must exist, optionally followed by an
+// operator and a value, e.g. `status == "UP"`, `replicas_ready >= 3`, `version =~ ^2\.`, `env in ["prod","stage"]`
+// or `tags is array`. The value is JSON, or a bare string.
+func ValidateExpectBodyJSON(expectation string) error {
+	_, err := parseJSONExpectation(expectation)
+
+	return err
+}
+
+// parseJSONExpectation parses a JSON expectation
+func parseJSONExpectation(expectation string) (*jsonExpectation, error) {
+	e := &jsonExpectation{expectation: expectation, path: expectation}
+
+	path, op, raw, found := splitJSONExpectation(expectation)
+	if !found {
+		return e, nil
+	}
+
+	e.path, e.op = path, op
+	if e.path == "" || raw == "" {
+		return nil, fmt.Errorf("%w %q: missing path or value", ErrInvalidJSONExpectation, expectation)
+	}
+
+	switch op {
+	case jsonOpMatch:
+		regex, err := regexp.Compile(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidJSONExpectation, expectation, err)
+		}
+		e.regex = regex
+	case jsonOpIs:
+		if _, ok := jsonTypes[raw]; !ok {
+			return nil, fmt.Errorf("%w %q: unknown type %q", ErrInvalidJSONExpectation, expectation, raw)
+		}
+		e.values = []gjson.Result{{Type: gjson.String, Str: raw}}
+	case jsonOpIn:
+		if gjson.Valid(raw) && gjson.Parse(raw).IsArray() {
+			e.values = gjson.Parse(raw).Array()
+		} else {
+			for _, item := range strings.Split(raw, ",") {
+				e.values = append(e.values, parseJSONValue(strings.TrimSpace(item)))
+			}
+		}
+	default:
+		e.values = []gjson.Result{parseJSONValue(raw)}
+	}
+
+	return e, nil
+}
+
+// splitJSONExpectation splits the expectation at its first operator between spaces that is out of the
+// brackets, parentheses and quotes of the path, e.g. of the GJSON queries.
+func splitJSONExpectation(expectation string) (path, op, value string, found bool) {
+	depth := 0
+	quoted := false
+	for i := 0; i < len(expectation); i++ {
+		switch c := expectation[i]; {
+		case c == '\\' && quoted:
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case c == ' ' && depth == 0:
+			for _, op := range jsonOps {
+				if rest, ok := strings.CutPrefix(expectation[i+1:], op+" "); ok {
+					return strings.TrimSpace(expectation[:i]), op, strings.TrimSpace(rest), true
+				}
+			}
+		}
+	}
+
+	return "", "", "", false
+}
+
+// parseJSONValue parses a JSON value, or else a bare string
+func parseJSONValue(raw string) gjson.Result {
+	if gjson.Valid(raw) {
+		return gjson.Parse(raw)
+	}
+
+	return gjson.Result{Type: gjson.String, Str: raw, Raw: raw}
+}
+
+// match reports whether the actual value matches the operator and the values
+func (e *jsonExpectation) match(actual gjson.Result) (bool, error) {
+	switch e.op {
+	case jsonOpExists:
+		return true, nil
+	case jsonOpEqual:
+		return jsonEqual(actual, e.values[0]), nil
+	case jsonOpNotEqual:
+		return !jsonEqual(actual, e.values[0]), nil
+	case jsonOpMatch:
+		return e.regex.MatchString(actual.String()), nil
+	case jsonOpIs:
+		return jsonTypes[e.values[0].Str](actual), nil
+	case jsonOpIn:
+		for _, value := range e.values {
+			if jsonEqual(actual, value) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	expected := e.values[0]
+	var order int
+	switch {
+	case actual.Type == gjson.Number && expected.Type == gjson.Number:
+		order = cmp.Compare(actual.Num, expected.Num)
+	case actual.Type == gjson.String && expected.Type == gjson.String:
+		order = strings.Compare(actual.Str, expected.Str)
+	default:
+		return false, fmt.Errorf("can't compare %s with %s", actual.Type, expected.Type)
+	}
+
+	switch e.op {
+	case jsonOpLess:
+		return order < 0, nil
+	case jsonOpLessEq:
+		return order <= 0, nil
+	case jsonOpGreater:
+		return order > 0, nil
+	default:
+		return order >= 0, nil
+	}
+}
+
+// jsonEqual reports whether the JSON values are equal
+func jsonEqual(a, b gjson.Result) bool {
+	return reflect.DeepEqual(a.Value(), b.Value())
+}
@@ -0,0 +1,90 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/checker"
+)
+
+// TestJSONExpectation tests comparing the JSON values of the body
+func TestJSONExpectation(t *testing.T) {
+	body := `{"status": "UP", "replicas_ready": 3, "version": "2.4.1", "ready": true, "env": "prod",
+		"checks": [{"name": "db", "status": "UP"}, {"name": "cache", "status": "DOWN"}], "owner": null}`
+	h := &HTTP{}
+
+	tests := []struct {
+		expectation string
+		matched     bool
+	}{
+		{"status", true},
+		{`status == "UP"`, true},
+		{"status == UP", true},
+		{`status != "UP"`, false},
+		{"replicas_ready >= 3", true},
+		{"replicas_ready > 3", false},
+		{"replicas_ready < 10", true},
+		{"replicas_ready <= 2", false},
+		{"replicas_ready == 3", true},
+		{`replicas_ready == "3"`, false},
+		{`version >= "2.3"`, true},
+		{`version =~ ^2\.4\.`, true},
+		{`version =~ ^3\.`, false},
+		{"ready == true", true},
+		{`env in ["prod","staging"]`, true},
+		{"env in staging, qa", false},
+		{"replicas_ready in [1, 3]", true},
+		{"checks is array", true},
+		{"status is number", false},
+		{"owner is null", true},
+		{"ready is bool", true},
+		{`checks.#(name == "db").status == "UP"`, true},
+		{`checks.#(name == "cache").status == "UP"`, false},
+		{"checks.# == 2", true},
+	}
+
+	for _, tt := range tests {
+		err := h.checkingJSONExpectation(body, tt.expectation)
+		if tt.matched {
+			assert.NoError(t, err, tt.expectation)
+		} else {
+			assert.Error(t, err, tt.expectation)
+		}
+	}
+}
+
+// TestJSONExpectationDetails tests that a failed expectation has the actual value in its details
+func TestJSONExpectationDetails(t *testing.T) {
+	h := &HTTP{}
+
+	var expectedError *checker.ExpectedError
+	assert.ErrorAs(t, h.checkingJSONExpectation(`{"replicas_ready": 2}`, "replicas_ready >= 3"), &expectedError)
+	assert.Equal(t, "the JSON value doesn't match", expectedError.Error())
+	assert.Equal(t, []any{"actual", "2", "expect", "replicas_ready >= 3"}, expectedError.Details())
+
+	assert.ErrorAs(t, h.checkingJSONExpectation(`{"replicas_ready": "2"}`, "replicas_ready >= 3"), &expectedError)
+	assert.Equal(t, "the JSON value can't be compared, caused by: can't compare String with Number", expectedError.Error())
+}
+
+// TestValidateExpectBodyJSON tests the validation of the JSON expectations
+func TestValidateExpectBodyJSON(t *testing.T) {
+	assert.NoError(t, ValidateExpectBodyJSON("status"))
+	assert.NoError(t, ValidateExpectBodyJSON(`status == "UP"`))
+	assert.ErrorIs(t, ValidateExpectBodyJSON("version =~ ("), ErrInvalidJSONExpectation)
+	assert.ErrorIs(t, ValidateExpectBodyJSON("status is text"), ErrInvalidJSONExpectation)
+	assert.ErrorIs(t, ValidateExpectBodyJSON(" == UP"), ErrInvalidJSONExpectation)
+}
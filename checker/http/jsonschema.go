@@ -0,0 +1,74 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// jsonSchema is the JSON schema of the body, that is compiled once on the first check
+type jsonSchema struct {
+	path   string
+	once   sync.Once
+	schema *jsonschema.Schema
+	err    error
+}
+
+// ValidateExpectBodyJSONSchema validates the JSON schema file
+func ValidateExpectBodyJSONSchema(path string) error {
+	_, err := compileJSONSchema(path)
+
+	return err
+}
+
+// compileJSONSchema compiles the JSON schema file
+func compileJSONSchema(path string) (*jsonschema.Schema, error) {
+	schema, err := jsonschema.NewCompiler().Compile(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't compile the JSON schema %s: %w", path, err)
+	}
+
+	return schema, nil
+}
+
+// compile returns the compiled JSON schema
+func (s *jsonSchema) compile() (*jsonschema.Schema, error) {
+	s.once.Do(func() {
+		s.schema, s.err = compileJSONSchema(s.path)
+	})
+
+	return s.schema, s.err
+}
+
+// validationErrors returns the JSON pointers of the invalid values of the body, with the reasons
+func validationErrors(err *jsonschema.ValidationError) []string {
+	var errs []string
+	for _, unit := range err.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+
+		location := unit.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		errs = append(errs, fmt.Sprintf("%s: %s", location, unit.Error))
+	}
+
+	return errs
+}
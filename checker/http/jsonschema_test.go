@@ -0,0 +1,88 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/checker"
+)
+
+// statusSchema is the JSON schema of the status with a feature flag
+const statusSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["status", "replicas", "feature_x"],
+	"properties": {
+		"status": {"enum": ["UP"]},
+		"replicas": {"type": "integer", "minimum": 1}
+	}
+}`
+
+// TestHttpExpectBodyJSONSchema tests the JSON schema expectation of the body.
+func TestHttpExpectBodyJSONSchema(t *testing.T) {
+	schemaFile := filepath.Join(t.TempDir(), "status.schema.json")
+	assert.NoError(t, os.WriteFile(schemaFile, []byte(statusSchema), 0o600))
+
+	body := `{"status": "UP", "replicas": 3, "feature_x": true}`
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer ts.Close()
+
+	hc := New(ts.URL, WithExpectBodyJSONSchema(schemaFile))
+	assert.NoError(t, hc.Check(context.Background()))
+
+	body = `{"status": "DOWN", "replicas": "3"}`
+	var expectedError *checker.ExpectedError
+	assert.ErrorAs(t, hc.Check(context.Background()), &expectedError)
+	assert.Equal(t, "the body doesn't validate against the JSON schema", expectedError.Error())
+
+	details := expectedError.Details()
+	assert.Equal(t, []any{"schema", schemaFile, "errors"}, details[:3])
+	errs := details[3].([]string)
+	assert.Len(t, errs, 3)
+	assert.Contains(t, errs[0], "/: missing property 'feature_x'")
+	assert.Contains(t, errs, "/status: value must be 'UP'")
+	assert.Contains(t, errs, "/replicas: got string, want integer")
+
+	body = `not json`
+	assert.ErrorAs(t, hc.Check(context.Background()), &expectedError)
+	assert.ErrorContains(t, expectedError, "the body isn't JSON")
+}
+
+// TestValidateExpectBodyJSONSchema tests the validation of the JSON schema file.
+func TestValidateExpectBodyJSONSchema(t *testing.T) {
+	dir := t.TempDir()
+	invalidFile := filepath.Join(dir, "invalid.schema.json")
+	assert.NoError(t, os.WriteFile(invalidFile, []byte(`{"type": 1}`), 0o600))
+
+	assert.Error(t, ValidateExpectBodyJSONSchema(invalidFile))
+	assert.Error(t, ValidateExpectBodyJSONSchema(filepath.Join(dir, "missing.json")))
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer ts.Close()
+
+	err := New(ts.URL, WithExpectBodyJSONSchema(invalidFile)).Check(context.Background())
+	assert.ErrorContains(t, err, "can't compile the JSON schema")
+}
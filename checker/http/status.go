@@ -0,0 +1,148 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidStatusCodes defines the error of invalid expected status codes
+var ErrInvalidStatusCodes = errors.New("invalid status codes")
+
+// statusCodeRange is an inclusive range of status codes
+type statusCodeRange struct {
+	from, to int
+}
+
+// StatusCodeMatcher matches the status codes of the responses. A code matches when it's in one of the
+// included codes, or there are none, and isn't in any of the excluded ones.
+type StatusCodeMatcher struct {
+	expression string
+	include    []statusCodeRange
+	exclude    []statusCodeRange
+}
+
+// StatusCodes returns the matcher of the status codes
+func StatusCodes(codes ...int) StatusCodeMatcher {
+	m := StatusCodeMatcher{}
+
+	expressions := make([]string, 0, len(codes))
+	for _, code := range codes {
+		m.include = append(m.include, statusCodeRange{code, code})
+		expressions = append(expressions, strconv.Itoa(code))
+	}
+	m.expression = strings.Join(expressions, ",")
+
+	return m
+}
+
+// ParseStatusCodes parses a comma-separated list of status codes (200), classes (2xx) and ranges (200-299),
+// the ones with a "!" prefix are excluded, e.g. "200,204", "2xx,!204" or "!5xx".
+func ParseStatusCodes(expression string) (StatusCodeMatcher, error) {
+	m := StatusCodeMatcher{expression: expression}
+
+	for _, term := range strings.Split(expression, ",") {
+		term = strings.TrimSpace(term)
+
+		excluded := strings.HasPrefix(term, "!")
+		r, err := parseStatusCodeRange(strings.TrimPrefix(term, "!"))
+		if err != nil {
+			return StatusCodeMatcher{}, fmt.Errorf("%w %q: %w", ErrInvalidStatusCodes, expression, err)
+		}
+
+		if excluded {
+			m.exclude = append(m.exclude, r)
+		} else {
+			m.include = append(m.include, r)
+		}
+	}
+
+	return m, nil
+}
+
+// parseStatusCodeRange parses a status code, class or range
+func parseStatusCodeRange(term string) (statusCodeRange, error) {
+	if class, ok := strings.CutSuffix(strings.ToLower(term), "xx"); ok {
+		digit, err := strconv.Atoi(class)
+		if err != nil || len(class) != 1 || digit < 1 {
+			return statusCodeRange{}, fmt.Errorf("invalid class %q", term)
+		}
+
+		return statusCodeRange{digit * 100, digit*100 + 99}, nil
+	}
+
+	from, to, isRange := strings.Cut(term, "-")
+	if !isRange {
+		to = from
+	}
+
+	r := statusCodeRange{}
+	var err error
+	if r.from, err = parseStatusCode(from); err != nil {
+		return statusCodeRange{}, err
+	}
+	if r.to, err = parseStatusCode(to); err != nil {
+		return statusCodeRange{}, err
+	}
+	if r.from > r.to {
+		return statusCodeRange{}, fmt.Errorf("invalid range %q", term)
+	}
+
+	return r, nil
+}
+
+// parseStatusCode parses a status code of three digits
+func parseStatusCode(s string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || code < 100 || code > 999 {
+		return 0, fmt.Errorf("invalid status code %q", s)
+	}
+
+	return code, nil
+}
+
+// Match reports whether the status code matches
+func (m StatusCodeMatcher) Match(code int) bool {
+	for _, r := range m.exclude {
+		if r.contains(code) {
+			return false
+		}
+	}
+
+	if len(m.include) == 0 {
+		return true
+	}
+
+	for _, r := range m.include {
+		if r.contains(code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// String returns the expression of the matcher
+func (m StatusCodeMatcher) String() string {
+	return m.expression
+}
+
+// contains reports whether the range contains the status code
+func (r statusCodeRange) contains(code int) bool {
+	return code >= r.from && code <= r.to
+}
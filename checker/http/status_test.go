@@ -0,0 +1,89 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/checker"
+)
+
+// TestParseStatusCodes tests matching the status codes of the lists, classes and ranges
+func TestParseStatusCodes(t *testing.T) {
+	tests := []struct {
+		expression string
+		matched    []int
+		unmatched  []int
+	}{
+		{"200", []int{200}, []int{201, 204}},
+		{"200,204", []int{200, 204}, []int{201, 500}},
+		{"2xx", []int{200, 204, 299}, []int{199, 300}},
+		{"2XX", []int{200}, []int{301}},
+		{"200-299", []int{200, 250, 299}, []int{199, 300}},
+		{"301, 302, 307, 308", []int{301, 308}, []int{303}},
+		{"!5xx", []int{200, 404, 302}, []int{500, 503}},
+		{"2xx,!204", []int{200, 201}, []int{204, 500}},
+		{"2xx,3xx,!304", []int{200, 301}, []int{304, 404}},
+	}
+
+	for _, tt := range tests {
+		m, err := ParseStatusCodes(tt.expression)
+		assert.NoError(t, err, tt.expression)
+		assert.Equal(t, tt.expression, m.String())
+
+		for _, code := range tt.matched {
+			assert.True(t, m.Match(code), "%s should match %d", tt.expression, code)
+		}
+		for _, code := range tt.unmatched {
+			assert.False(t, m.Match(code), "%s shouldn't match %d", tt.expression, code)
+		}
+	}
+}
+
+// TestParseStatusCodesInvalid tests the invalid status codes
+func TestParseStatusCodesInvalid(t *testing.T) {
+	for _, expression := range []string{"", "abc", "20", "2000", "0xx", "10xx", "299-200", "200-", "200,,204", "!"} {
+		_, err := ParseStatusCodes(expression)
+		assert.ErrorIs(t, err, ErrInvalidStatusCodes, expression)
+	}
+}
+
+// TestHttpExpectStatusCodes tests the HTTP checker with a status code matcher
+func TestHttpExpectStatusCodes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	m, err := ParseStatusCodes("200,204")
+	assert.NoError(t, err)
+	assert.NoError(t, New(ts.URL, WithExpectStatusCodes(m)).Check(context.Background()))
+	assert.NoError(t, New(ts.URL, WithExpectStatusCodes(StatusCodes(200, 204))).Check(context.Background()))
+
+	m, err = ParseStatusCodes("!2xx")
+	assert.NoError(t, err)
+
+	var expectedError *checker.ExpectedError
+	assert.ErrorAs(t, New(ts.URL, WithExpectStatusCodes(m)).Check(context.Background()), &expectedError)
+	assert.Equal(t, []any{"actual", http.StatusNoContent, "expect", "!2xx"}, expectedError.Details())
+
+	// The int option is kept, and 0 expects none.
+	assert.NoError(t, New(ts.URL, WithExpectStatusCodes(m), WithExpectStatusCode(0)).Check(context.Background()))
+	assert.Error(t, New(ts.URL, WithExpectStatusCode(http.StatusOK)).Check(context.Background()))
+}
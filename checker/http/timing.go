@@ -0,0 +1,96 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"wait4x.dev/v3/checker"
+)
+
+// timings records the phases of a request with an httptrace.ClientTrace
+type timings struct {
+	mu                        sync.Mutex
+	start                     time.Time
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	firstByte                 time.Time
+}
+
+// withTimings returns a context that records the timings of the request
+func withTimings(ctx context.Context) (context.Context, *timings) {
+	t := &timings{}
+	record := func(at *time.Time, first bool) {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		// The connections may be dialed to several addresses, the phases span all of them.
+		if first && !at.IsZero() {
+			return
+		}
+		*at = time.Now()
+	}
+
+	return httptrace.WithClientTrace(ctx, &httptrace.ClientTrace{
+		DNSStart:             func(httptrace.DNSStartInfo) { record(&t.dnsStart, true) },
+		DNSDone:              func(httptrace.DNSDoneInfo) { record(&t.dnsDone, false) },
+		ConnectStart:         func(string, string) { record(&t.connectStart, true) },
+		ConnectDone:          func(string, string, error) { record(&t.connectDone, false) },
+		TLSHandshakeStart:    func() { record(&t.tlsStart, true) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { record(&t.tlsDone, false) },
+		GotFirstResponseByte: func() { record(&t.firstByte, true) },
+	}), t
+}
+
+// begin records the start of the request
+func (t *timings) begin() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.start = time.Now()
+}
+
+// since returns the time since the start of the request
+func (t *timings) since() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return time.Since(t.start)
+}
+
+// observe observes the timings of the phases that took place, a reused connection has no
+// DNS, connect and TLS phases.
+func (t *timings) observe(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	phases := []struct {
+		name       string
+		start, end time.Time
+	}{
+		{ObservationDNSTime, t.dnsStart, t.dnsDone},
+		{ObservationConnectTime, t.connectStart, t.connectDone},
+		{ObservationTLSTime, t.tlsStart, t.tlsDone},
+		{ObservationFirstByteTime, t.start, t.firstByte},
+	}
+	for _, phase := range phases {
+		if !phase.start.IsZero() && !phase.end.IsZero() {
+			checker.Observe(ctx, phase.name, phase.end.Sub(phase.start))
+		}
+	}
+	checker.Observe(ctx, ObservationTotalTime, time.Since(t.start))
+}
@@ -0,0 +1,94 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/checker"
+)
+
+// TestHttpTimings tests the observations of the timings of the request phases
+func TestHttpTimings(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {}))
+	defer ts.Close()
+
+	hc := New(ts.URL, WithTLSConfig(ts.Client().Transport.(*http.Transport).TLSClientConfig), WithPersistent(true))
+
+	ctx, observations := checker.WithObservations(context.Background())
+	assert.NoError(t, hc.Check(ctx))
+
+	for _, name := range []string{ObservationConnectTime, ObservationTLSTime, ObservationFirstByteTime, ObservationTotalTime} {
+		duration, ok := checker.ObservationValue[time.Duration](observations(), name)
+		assert.True(t, ok, name)
+		assert.Positive(t, duration, name)
+	}
+
+	// The persistent connection is reused, so it has no connect and TLS phases.
+	ctx, observations = checker.WithObservations(context.Background())
+	assert.NoError(t, hc.Check(ctx))
+
+	_, ok := checker.ObservationValue[time.Duration](observations(), ObservationConnectTime)
+	assert.False(t, ok)
+	_, ok = checker.ObservationValue[time.Duration](observations(), ObservationTLSTime)
+	assert.False(t, ok)
+	_, ok = checker.ObservationValue[time.Duration](observations(), ObservationTotalTime)
+	assert.True(t, ok)
+	assert.NoError(t, hc.(*HTTP).Close())
+}
+
+// TestHttpExpectResponseTime tests the expected response time of consecutive checks
+func TestHttpExpectResponseTime(t *testing.T) {
+	var slow atomic.Bool
+	ts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		if slow.Load() {
+			time.Sleep(100 * time.Millisecond)
+		}
+	}))
+	defer ts.Close()
+
+	var expectedError *checker.ExpectedError
+
+	slow.Store(true)
+	err := New(ts.URL, WithExpectResponseTime(50*time.Millisecond)).Check(context.Background())
+	assert.True(t, errors.As(err, &expectedError))
+	assert.ErrorContains(t, err, "the response time is longer than expected")
+
+	slow.Store(false)
+	assert.NoError(t, New(ts.URL, WithExpectResponseTime(time.Second)).Check(context.Background()))
+
+	hc := New(ts.URL, WithExpectResponseTime(50*time.Millisecond), WithExpectResponseTimeConsecutive(3))
+	for range 2 {
+		err = hc.Check(context.Background())
+		assert.True(t, errors.As(err, &expectedError))
+		assert.ErrorContains(t, err, "not enough consecutive responses")
+	}
+
+	// A slow response starts the consecutive checks again.
+	slow.Store(true)
+	assert.Error(t, hc.Check(context.Background()))
+	slow.Store(false)
+	for range 2 {
+		assert.Error(t, hc.Check(context.Background()))
+	}
+	assert.NoError(t, hc.Check(context.Background()))
+}
@@ -0,0 +1,46 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"strings"
+)
+
+// UnixScheme is the scheme of the targets on a Unix domain socket, e.g. unix:///var/run/app.sock:/health
+const UnixScheme = "unix"
+
+// WithUnixSocket configures the Unix domain socket that the connections are dialed to, the host
+// of the URL is only sent in the Host header, like the --unix-socket option of curl.
+func WithUnixSocket(path string) Option {
+	return func(h *HTTP) {
+		h.unixSocket = path
+	}
+}
+
+// parseUnixTarget returns the socket and the request URL of a unix:///path/to.sock:/request/path
+// target, the request path defaults to /.
+func parseUnixTarget(address string) (socket, requestURL string, ok bool) {
+	rest, ok := strings.CutPrefix(address, UnixScheme+"://")
+	if !ok {
+		return "", "", false
+	}
+
+	socket, path, _ := strings.Cut(rest, ":")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	return socket, "http://localhost" + path, true
+}
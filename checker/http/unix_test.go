@@ -0,0 +1,119 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package http
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wait4x.dev/v3/checker"
+)
+
+// TestParseUnixTarget tests parsing the targets on a Unix domain socket
+func TestParseUnixTarget(t *testing.T) {
+	tests := []struct {
+		address, socket, requestURL string
+	}{
+		{"unix:///var/run/app.sock:/health", "/var/run/app.sock", "http://localhost/health"},
+		{"unix:///var/run/app.sock:/health?verbose=1", "/var/run/app.sock", "http://localhost/health?verbose=1"},
+		{"unix:///var/run/app.sock", "/var/run/app.sock", "http://localhost/"},
+		{"unix:///var/run/app.sock:ready", "/var/run/app.sock", "http://localhost/ready"},
+	}
+	for _, tt := range tests {
+		socket, requestURL, ok := parseUnixTarget(tt.address)
+		assert.True(t, ok, tt.address)
+		assert.Equal(t, tt.socket, socket, tt.address)
+		assert.Equal(t, tt.requestURL, requestURL, tt.address)
+	}
+
+	_, _, ok := parseUnixTarget("http://localhost/health")
+	assert.False(t, ok)
+}
+
+// unixServer returns the path of a Unix domain socket that serves the handler
+func unixServer(t *testing.T, handler http.Handler) string {
+	// The socket paths are limited to about 100 bytes, so the temporary directory of the test may be too long.
+	dir, err := os.MkdirTemp("", "wait4x")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "app.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: handler}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	return socket
+}
+
+// TestHttpUnixSocket tests the expectations of the requests over a Unix domain socket
+func TestHttpUnixSocket(t *testing.T) {
+	var host string
+	socket := unixServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"status": "UP", "checks": [{"name": "db"}]}`))
+	}))
+
+	expectations := []Option{
+		WithExpectStatusCode(http.StatusOK),
+		WithExpectHeader("Content-Type=application/json"),
+		WithExpectBodyJSON(`status == "UP"`),
+		WithExpectBodyRegex(`"db"`),
+	}
+
+	hc := New("unix://"+socket+":/health", expectations...)
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.Equal(t, "localhost", host)
+
+	hc = New("http://app.local/health", append(expectations, WithUnixSocket(socket))...)
+	assert.NoError(t, hc.Check(context.Background()))
+	assert.Equal(t, "app.local", host)
+
+	hc = New("unix://"+socket+":/missing", WithExpectStatusCode(http.StatusOK))
+	assert.ErrorContains(t, hc.Check(context.Background()), "the status code doesn't expect")
+
+	// The socket that isn't created yet is waited for.
+	var expectedError *checker.ExpectedError
+	err := New("unix://" + socket + ".missing:/health").Check(context.Background())
+	assert.True(t, errors.As(err, &expectedError))
+}
+
+// TestHttpUnixSocketXPath tests the XPath expectation over a Unix domain socket
+func TestHttpUnixSocketXPath(t *testing.T) {
+	socket := unixServer(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`<html><body><div id="status">ready</div></body></html>`))
+	}))
+
+	hc := New("unix://"+socket+":/", WithExpectBodyXPath(`//div[@id="status"]`))
+	assert.NoError(t, hc.Check(context.Background()))
+
+	desc, err := checker.Describe(hc)
+	require.NoError(t, err)
+	assert.Equal(t, UnixScheme, desc.Protocol)
+}
@@ -17,33 +17,81 @@ package influxdb
 
 import (
 	"context"
+	"crypto/tls"
+	"net/http"
+	"time"
+
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 )
 
+// Option configures an InfluxDB checker
+type Option func(i *InfluxDB)
+
 // InfluxDB is an InfluxDB checker
 type InfluxDB struct {
 	serverURL string
+	tlsConfig *tls.Config
+	dialer    dialer.DialFunc
 }
 
 // New creates a new InfluxDB checker
-func New(serverURL string) checker.Checker {
+func New(serverURL string, opts ...Option) checker.Checker {
 	i := &InfluxDB{
 		serverURL: serverURL,
 	}
 
+	// apply the list of options to InfluxDB
+	for _, opt := range opts {
+		opt(i)
+	}
+
 	return i
 }
 
+// WithTLSConfig configures the TLS config of https:// server URLs
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(i *InfluxDB) {
+		i.tlsConfig = tlsConfig
+	}
+}
+
+// WithDialer configures the dialer of the connections, the proxy of the environment is still used
+func WithDialer(d dialer.DialFunc) Option {
+	return func(i *InfluxDB) {
+		i.dialer = d
+	}
+}
+
 // Identity returns the identity of the InfluxDB checker
 func (i *InfluxDB) Identity() (string, error) {
 	return i.serverURL, nil
 }
 
+// Describe returns the description of the InfluxDB checker
+func (i *InfluxDB) Describe() (checker.Description, error) {
+	return checker.DescribeURL("InfluxDB", i.serverURL)
+}
+
 // Check checks the InfluxDB connection
 func (i *InfluxDB) Check(ctx context.Context) error {
 	// InfluxDB doesn't validate authentication params on Ping and Health requests.
-	ic := influxdb2.NewClient(i.serverURL, "")
+	options := influxdb2.DefaultOptions().SetTLSConfig(i.tlsConfig)
+	if i.dialer != nil {
+		// The client only takes a custom dialer through its HTTP client.
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = i.tlsConfig
+		transport.DialContext = i.dialer
+		defer transport.CloseIdleConnections()
+
+		options.SetHTTPClient(&http.Client{
+			Timeout:   time.Duration(options.HTTPRequestTimeout()) * time.Second,
+			Transport: transport,
+		})
+	}
+
+	ic := influxdb2.NewClientWithOptions(i.serverURL, "", options)
 	defer ic.Close()
 
 	res, err := ic.Ping(ctx)
@@ -0,0 +1,49 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdb
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"wait4x.dev/v3/internal/test"
+)
+
+// TestCheckNoFDLeak tests that the InfluxDB checks release their connections
+func TestCheckNoFDLeak(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ping" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer ts.Close()
+
+	chk := New(ts.URL)
+	test.AssertFDsBounded(t, 200, 10, func() {
+		assert.NoError(t, chk.Check(context.Background()))
+	})
+
+	d := new(test.RecordingDialer)
+	chk = New(ts.URL, WithDialer(d.DialContext))
+	test.AssertFDsBounded(t, 200, 10, func() {
+		assert.NoError(t, chk.Check(context.Background()))
+	})
+	assert.Len(t, d.Addresses(), 200)
+}
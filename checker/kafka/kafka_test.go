@@ -24,6 +24,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/log"
 	"github.com/testcontainers/testcontainers-go/modules/kafka"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 // KafkaSuite is a test suite for Kafka checker
@@ -87,6 +88,26 @@ func (s *KafkaSuite) TestValidConnection() {
 	s.Assert().NoError(chk.Check(ctx))
 }
 
+// TestWithDialer tests the connection of the Kafka checker with a custom dialer
+func (s *KafkaSuite) TestWithDialer() {
+	bs, err := s.container.Brokers(context.Background())
+	s.Require().NoError(err)
+
+	d := new(test.RecordingDialer)
+	test.AssertDialerUsed(s.T(), d, New("kafka://"+bs[0], WithDialer(d.DialContext)).Check)
+}
+
+// TestCheckNoFDLeak tests that the Kafka checks release their connections
+func (s *KafkaSuite) TestCheckNoFDLeak() {
+	bs, err := s.container.Brokers(context.Background())
+	s.Require().NoError(err)
+
+	chk := New("kafka://" + bs[0])
+	test.AssertFDsBounded(s.T(), 100, 10, func() {
+		s.Assert().NoError(chk.Check(context.Background()))
+	})
+}
+
 // TestKafka runs the Kafka test suite
 func TestKafka(t *testing.T) {
 	suite.Run(t, new(KafkaSuite))
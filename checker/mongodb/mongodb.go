@@ -17,8 +17,8 @@ package mongodb
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
-	"regexp"
 	"strings"
 
 	"go.mongodb.org/mongo-driver/mongo"
@@ -26,24 +26,60 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 )
 
-var hidePasswordRegexp = regexp.MustCompile(`^(mongodb://[^/:]+):[^:@]+@`)
+// Option configures a MongoDB checker
+type Option func(m *MongoDB)
 
 // MongoDB is a MongoDB checker
 type MongoDB struct {
-	dsn string
+	dsn        string
+	tlsConfig  *tls.Config
+	dialer     dialer.DialFunc
+	persistent bool
+	client     *checker.PersistentClient[*mongo.Client]
 }
 
 // New creates a new MongoDB checker
-func New(dsn string) checker.Checker {
+func New(dsn string, opts ...Option) checker.Checker {
 	i := &MongoDB{
 		dsn: dsn,
 	}
 
+	// apply the list of options to MongoDB
+	for _, opt := range opts {
+		opt(i)
+	}
+
+	i.client = checker.NewPersistentClient(i.persistent, func(c *mongo.Client) error {
+		return c.Disconnect(context.Background())
+	})
+
 	return i
 }
 
+// WithTLSConfig configures TLS for the connections, it takes precedence over the tls options of the DSN
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(m *MongoDB) {
+		m.tlsConfig = tlsConfig
+	}
+}
+
+// WithDialer configures the dialer of the connections, e.g. a proxy dialer
+func WithDialer(d dialer.DialFunc) Option {
+	return func(m *MongoDB) {
+		m.dialer = d
+	}
+}
+
+// WithPersistent keeps the client between the checks, it's rebuilt after a connection failure
+func WithPersistent(persistent bool) Option {
+	return func(m *MongoDB) {
+		m.persistent = persistent
+	}
+}
+
 // Identity returns the identity of the MongoDB checker
 func (m *MongoDB) Identity() (string, error) {
 	cops := options.Client().ApplyURI(m.dsn)
@@ -54,19 +90,34 @@ func (m *MongoDB) Identity() (string, error) {
 	return strings.Join(cops.Hosts, ","), nil
 }
 
+// Describe returns the description of the MongoDB checker
+func (m *MongoDB) Describe() (checker.Description, error) {
+	identity, err := m.Identity()
+	if err != nil {
+		return checker.Description{}, err
+	}
+
+	scheme, _, _ := strings.Cut(m.dsn, "://")
+
+	return checker.DescribeAddress("MongoDB", scheme, identity), nil
+}
+
 // Check checks the MongoDB connection
 func (m *MongoDB) Check(ctx context.Context) (err error) {
-	// Creates a new Client and then initializes it using the Connect method.
-	c, err := mongo.Connect(ctx, options.Client().ApplyURI(m.dsn))
+	c, err := m.client.Get(func() (*mongo.Client, error) {
+		return m.connect(ctx)
+	})
 	if err != nil {
 		return err
 	}
 
-	defer func(c *mongo.Client, ctx context.Context) {
-		if merr := c.Disconnect(ctx); merr != nil {
+	defer func() {
+		// Only a connection-level failure rebuilds the client, not e.g. an authentication error.
+		broken := checker.IsConnectionError(err) || errors.Is(err, topology.ErrServerSelectionTimeout)
+		if merr := m.client.Release(c, broken); merr != nil {
 			err = merr
 		}
-	}(c, ctx)
+	}()
 
 	// Ping the primary
 	err = c.Ping(ctx, readpref.Primary())
@@ -74,7 +125,7 @@ func (m *MongoDB) Check(ctx context.Context) (err error) {
 		if checker.IsConnectionRefused(err) || errors.Is(err, topology.ErrServerSelectionTimeout) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the MongoDB server", err,
-				"dsn", hidePasswordRegexp.ReplaceAllString(m.dsn, `$1:***@`),
+				"dsn", m.dsn,
 			)
 		}
 
@@ -83,3 +134,22 @@ func (m *MongoDB) Check(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// Close disconnects the client of the persistent mode
+func (m *MongoDB) Close() error {
+	return m.client.Close()
+}
+
+// connect creates a new Client and then initializes it using the Connect method
+func (m *MongoDB) connect(ctx context.Context) (*mongo.Client, error) {
+	cops := options.Client().ApplyURI(m.dsn)
+	if m.tlsConfig != nil {
+		// The driver sets the server name of each host of a replica set.
+		cops.SetTLSConfig(m.tlsConfig.Clone())
+	}
+	if m.dialer != nil {
+		cops.SetDialer(m.dialer)
+	}
+
+	return mongo.Connect(ctx, cops)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/log"
 	"github.com/testcontainers/testcontainers-go/modules/mongodb"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 // MongoDBSuite is a test suite for MongoDB checker
@@ -86,6 +87,26 @@ func (s *MongoDBSuite) TestValidConnection() {
 	s.Assert().Nil(chk.Check(ctx))
 }
 
+// TestWithDialer tests the connection of the MongoDB checker with a custom dialer
+func (s *MongoDBSuite) TestWithDialer() {
+	endpoint, err := s.container.ConnectionString(context.Background())
+	s.Require().NoError(err)
+
+	d := new(test.RecordingDialer)
+	test.AssertDialerUsed(s.T(), d, New(endpoint, WithDialer(d.DialContext)).Check)
+}
+
+// TestCheckNoFDLeak tests that the MongoDB checks release their clients
+func (s *MongoDBSuite) TestCheckNoFDLeak() {
+	endpoint, err := s.container.ConnectionString(context.Background())
+	s.Require().NoError(err)
+
+	chk := New(endpoint)
+	test.AssertFDsBounded(s.T(), 100, 10, func() {
+		s.Assert().NoError(chk.Check(context.Background()))
+	})
+}
+
 // TestMongoDB runs the MongoDB test suite
 func TestMongoDB(t *testing.T) {
 	suite.Run(t, new(MongoDBSuite))
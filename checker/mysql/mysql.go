@@ -17,16 +17,15 @@ package mysql
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"fmt"
-	"regexp"
 
 	"github.com/go-sql-driver/mysql"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 )
 
-var hidePasswordRegexp = regexp.MustCompile(`^([^:]+):[^:@]+@`)
-
 const (
 	expectTableQuery = "SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = '%s')"
 )
@@ -35,6 +34,10 @@ const (
 type MySQL struct {
 	dsn         string
 	expectTable string
+	tlsConfig   *tls.Config
+	dialer      dialer.DialFunc
+	persistent  bool
+	db          *checker.PersistentClient[*sql.DB]
 }
 
 // Option is a function that configures the MySQL checker
@@ -51,6 +54,8 @@ func New(dsn string, opts ...Option) checker.Checker {
 		opt(m)
 	}
 
+	m.db = checker.NewPersistentClient(m.persistent, (*sql.DB).Close)
+
 	return m
 }
 
@@ -61,6 +66,27 @@ func WithExpectTable(table string) Option {
 	}
 }
 
+// WithTLSConfig configures TLS for the connection, it takes precedence over the tls parameter of the DSN
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(m *MySQL) {
+		m.tlsConfig = tlsConfig
+	}
+}
+
+// WithDialer configures the dialer of the connection, e.g. a proxy dialer
+func WithDialer(d dialer.DialFunc) Option {
+	return func(m *MySQL) {
+		m.dialer = d
+	}
+}
+
+// WithPersistent keeps the connection pool between the checks, it's rebuilt after a connection failure
+func WithPersistent(persistent bool) Option {
+	return func(m *MySQL) {
+		m.persistent = persistent
+	}
+}
+
 // Identity returns the identity of the MySQL checker
 func (m *MySQL) Identity() (string, error) {
 	cfg, err := mysql.ParseDSN(m.dsn)
@@ -71,25 +97,43 @@ func (m *MySQL) Identity() (string, error) {
 	return cfg.Addr, nil
 }
 
+// Describe returns the description of the MySQL checker
+func (m *MySQL) Describe() (checker.Description, error) {
+	cfg, err := mysql.ParseDSN(m.dsn)
+	if err != nil {
+		return checker.Description{}, fmt.Errorf("can't describe the checker: %w", err)
+	}
+
+	desc := checker.DescribeAddress("MySQL", "mysql", cfg.Addr)
+	desc.Labels = map[string]string{"network": cfg.Net}
+	if cfg.DBName != "" {
+		desc.Labels["database"] = cfg.DBName
+	}
+
+	return desc, nil
+}
+
 // Check checks the MySQL connection
 func (m *MySQL) Check(ctx context.Context) (err error) {
-	db, err := sql.Open("mysql", m.dsn)
+	db, err := m.db.Get(m.open)
 	if err != nil {
 		return err
 	}
 
-	defer func(db *sql.DB) {
-		if dberr := db.Close(); dberr != nil {
+	defer func() {
+		// Only a connection-level failure rebuilds the client, not e.g. an authentication error.
+		broken := checker.IsConnectionError(err)
+		if dberr := m.db.Release(db, broken); dberr != nil {
 			err = dberr
 		}
-	}(db)
+	}()
 
 	err = db.PingContext(ctx)
 	if err != nil {
 		if checker.IsConnectionRefused(err) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the mysql server", err,
-				"dsn", hidePasswordRegexp.ReplaceAllString(m.dsn, `$1:***@`),
+				"dsn", m.dsn,
 			)
 		}
 
@@ -114,3 +158,33 @@ func (m *MySQL) Check(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// Close closes the connection pool of the persistent mode
+func (m *MySQL) Close() error {
+	return m.db.Close()
+}
+
+// open opens the database with the TLS config and the dialer, if any
+func (m *MySQL) open() (*sql.DB, error) {
+	cfg, err := mysql.ParseDSN(m.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.tlsConfig != nil {
+		// The driver defaults the server name to the host of the address.
+		cfg.TLS = m.tlsConfig.Clone()
+	}
+
+	if m.dialer != nil {
+		// The connector bounds the dial context with the timeout parameter of the DSN.
+		cfg.DialFunc = m.dialer
+	}
+
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return sql.OpenDB(connector), nil
+}
@@ -20,11 +20,13 @@ import (
 	"database/sql"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/log"
 	"github.com/testcontainers/testcontainers-go/modules/mysql"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 // MySQLSuite is a test suite for MySQL checker
@@ -89,6 +91,26 @@ func (s *MySQLSuite) TestValidAddress() {
 	s.Assert().Nil(chk.Check(ctx))
 }
 
+// TestWithDialer tests the connection of the MySQL checker with a custom dialer
+func (s *MySQLSuite) TestWithDialer() {
+	endpoint, err := s.container.ConnectionString(context.Background())
+	s.Require().NoError(err)
+
+	d := new(test.RecordingDialer)
+	test.AssertDialerUsed(s.T(), d, New(endpoint, WithDialer(d.DialContext)).Check)
+}
+
+// TestCheckNoFDLeak tests that the MySQL checks release their connections
+func (s *MySQLSuite) TestCheckNoFDLeak() {
+	endpoint, err := s.container.ConnectionString(context.Background())
+	s.Require().NoError(err)
+
+	chk := New(endpoint)
+	test.AssertFDsBounded(s.T(), 100, 10, func() {
+		s.Assert().NoError(chk.Check(context.Background()))
+	})
+}
+
 func (s *MySQLSuite) TestTableNotExists() {
 	var expectedError *checker.ExpectedError
 
@@ -127,3 +149,20 @@ func (s *MySQLSuite) TestExpectTable() {
 func TestMySQL(t *testing.T) {
 	suite.Run(t, new(MySQLSuite))
 }
+
+// TestDescribe tests the description of the MySQL checker
+func TestDescribe(t *testing.T) {
+	desc, err := New("user:secret@tcp(db:3306)/app").(checker.Describer).Describe()
+	assert.NoError(t, err)
+	assert.Equal(t, checker.Description{
+		Kind:     "MySQL",
+		Host:     "db",
+		Port:     "3306",
+		Protocol: "mysql",
+		Display:  "db:3306",
+		Labels:   map[string]string{"network": "tcp", "database": "app"},
+	}, desc)
+
+	_, err = New("invalid").(checker.Describer).Describe()
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"sync"
+)
+
+// Observation is a fact that a checker learned in a check, e.g. the status code of an HTTP response.
+// The checkers document the names and the types of the values of their observations.
+type Observation struct {
+	Name  string
+	Value any
+}
+
+// Observations are the observations of a check, in the order they were made
+type Observations []Observation
+
+// Lookup returns the value of the last observation with the name
+func (o Observations) Lookup(name string) (any, bool) {
+	for i := len(o) - 1; i >= 0; i-- {
+		if o[i].Name == name {
+			return o[i].Value, true
+		}
+	}
+
+	return nil, false
+}
+
+// KeysAndValues returns the observations as the key/value pairs of a logger
+func (o Observations) KeysAndValues() []any {
+	keysAndValues := make([]any, 0, 2*len(o))
+	for _, observation := range o {
+		keysAndValues = append(keysAndValues, observation.Name, observation.Value)
+	}
+
+	return keysAndValues
+}
+
+// ObservationValue returns the value of the last observation with the name, if it has the type T
+func ObservationValue[T any](o Observations, name string) (T, bool) {
+	value, ok := o.Lookup(name)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+
+	typed, ok := value.(T)
+
+	return typed, ok
+}
+
+// observationsKey is the context key of the observations recorder
+type observationsKey struct{}
+
+// observationsRecorder records the observations of a check, whose checks may run concurrently, e.g. with CheckAll
+type observationsRecorder struct {
+	mu           sync.Mutex
+	observations Observations
+}
+
+// WithObservations returns a context that records the observations of the checks made with it,
+// and a function that returns them.
+func WithObservations(ctx context.Context) (context.Context, func() Observations) {
+	recorder := new(observationsRecorder)
+
+	return context.WithValue(ctx, observationsKey{}, recorder), func() Observations {
+		recorder.mu.Lock()
+		defer recorder.mu.Unlock()
+
+		return append(Observations(nil), recorder.observations...)
+	}
+}
+
+// Observe records an observation of the check, when the context records them
+func Observe(ctx context.Context, name string, value any) {
+	recorder, ok := ctx.Value(observationsKey{}).(*observationsRecorder)
+	if !ok {
+		return
+	}
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.observations = append(recorder.observations, Observation{Name: name, Value: value})
+}
+
+// requestedObservationsKey is the context key of the names of the requested observations
+type requestedObservationsKey struct{}
+
+// RequestObservations returns a context that requests the observations with the names from the checks
+// made with it. The checkers only make their costly observations, e.g. the body of an HTTP response,
+// when they're requested.
+func RequestObservations(ctx context.Context, names ...string) context.Context {
+	requested := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		requested[name] = struct{}{}
+	}
+
+	return context.WithValue(ctx, requestedObservationsKey{}, requested)
+}
+
+// ObservationRequested reports whether the context requests the observation with the name
+func ObservationRequested(ctx context.Context, name string) bool {
+	requested, ok := ctx.Value(requestedObservationsKey{}).(map[string]struct{})
+	if !ok {
+		return false
+	}
+
+	_, ok = requested[name]
+
+	return ok
+}
+
+// Observer is the interface of the checkers that make observations in their checks
+type Observer interface {
+	// ObservationNames returns the names of the observations that the checks may make
+	ObservationNames() []string
+}
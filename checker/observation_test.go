@@ -0,0 +1,70 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestObservations tests recording the observations of a check
+func TestObservations(t *testing.T) {
+	ctx, observations := WithObservations(context.Background())
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Observe(ctx, "attempt", 1)
+		}()
+	}
+	wg.Wait()
+	Observe(ctx, "status_code", 200)
+
+	observed := observations()
+	assert.Len(t, observed, 11)
+
+	statusCode, ok := ObservationValue[int](observed, "status_code")
+	assert.True(t, ok)
+	assert.Equal(t, 200, statusCode)
+
+	_, ok = ObservationValue[string](observed, "status_code")
+	assert.False(t, ok)
+
+	_, ok = observed.Lookup("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, []any{"attempt", 1}, observed.KeysAndValues()[:2])
+}
+
+// TestObserveWithoutRecorder tests that the observations are dropped when the context doesn't record them
+func TestObserveWithoutRecorder(t *testing.T) {
+	assert.NotPanics(t, func() {
+		Observe(context.Background(), "status_code", 200)
+	})
+}
+
+// TestRequestObservations tests requesting the observations from the checks
+func TestRequestObservations(t *testing.T) {
+	ctx := RequestObservations(context.Background(), "body", "json")
+
+	assert.True(t, ObservationRequested(ctx, "json"))
+	assert.False(t, ObservationRequested(ctx, "status_code"))
+	assert.False(t, ObservationRequested(context.Background(), "json"))
+}
@@ -0,0 +1,91 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"sync"
+)
+
+// PersistentClient holds the client of a checker. In the persistent mode it keeps the client
+// between the checks, until a connection-level failure, otherwise it closes it after each check.
+type PersistentClient[T any] struct {
+	persistent bool
+	closeFn    func(T) error
+
+	mu     sync.Mutex
+	client T
+	kept   bool
+}
+
+// NewPersistentClient creates a new PersistentClient that closes the clients with closeFn
+func NewPersistentClient[T any](persistent bool, closeFn func(T) error) *PersistentClient[T] {
+	return &PersistentClient[T]{
+		persistent: persistent,
+		closeFn:    closeFn,
+	}
+}
+
+// Get returns the kept client, or a new one of newFn.
+func (p *PersistentClient[T]) Get(newFn func() (T, error)) (T, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.kept {
+		return p.client, nil
+	}
+
+	client, err := newFn()
+	if err != nil {
+		return client, err
+	}
+
+	if p.persistent {
+		p.client, p.kept = client, true
+	}
+
+	return client, nil
+}
+
+// Release ends a check with the client. It closes the client unless it's kept, and a broken
+// client, e.g. after a connection-level failure, is never kept.
+func (p *PersistentClient[T]) Release(client T, broken bool) error {
+	if !p.persistent {
+		return p.closeFn(client)
+	}
+
+	if !broken {
+		return nil
+	}
+
+	p.mu.Lock()
+	p.kept = false
+	p.mu.Unlock()
+
+	return p.closeFn(client)
+}
+
+// Close closes the kept client, if any.
+func (p *PersistentClient[T]) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.kept {
+		return nil
+	}
+
+	p.kept = false
+
+	return p.closeFn(p.client)
+}
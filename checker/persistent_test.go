@@ -0,0 +1,132 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testClient is a client that counts the clients and records if it's closed
+type testClient struct {
+	id     int
+	closed bool
+}
+
+// newTestClients returns the constructor and the closer of the test clients
+func newTestClients() (func() (*testClient, error), func(*testClient) error) {
+	var created int
+	newFn := func() (*testClient, error) {
+		created++
+		return &testClient{id: created}, nil
+	}
+	closeFn := func(c *testClient) error {
+		c.closed = true
+		return nil
+	}
+
+	return newFn, closeFn
+}
+
+// TestPersistentClientDisabled tests that a new client is used and closed for each check
+func TestPersistentClientDisabled(t *testing.T) {
+	newFn, closeFn := newTestClients()
+	p := NewPersistentClient(false, closeFn)
+
+	first, err := p.Get(newFn)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Release(first, false))
+	assert.True(t, first.closed)
+
+	second, err := p.Get(newFn)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, second.id)
+	assert.NoError(t, p.Release(second, false))
+	assert.NoError(t, p.Close())
+}
+
+// TestPersistentClient tests keeping the client until it's broken or closed
+func TestPersistentClient(t *testing.T) {
+	newFn, closeFn := newTestClients()
+	p := NewPersistentClient(true, closeFn)
+
+	first, err := p.Get(newFn)
+	assert.NoError(t, err)
+	assert.NoError(t, p.Release(first, false))
+	assert.False(t, first.closed)
+
+	again, err := p.Get(newFn)
+	assert.NoError(t, err)
+	assert.Same(t, first, again)
+
+	assert.NoError(t, p.Release(again, true))
+	assert.True(t, first.closed)
+
+	second, err := p.Get(newFn)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, second.id)
+
+	assert.NoError(t, p.Close())
+	assert.True(t, second.closed)
+	assert.NoError(t, p.Close())
+}
+
+// TestPersistentClientNewError tests that a failed client isn't kept
+func TestPersistentClientNewError(t *testing.T) {
+	_, closeFn := newTestClients()
+	p := NewPersistentClient(true, closeFn)
+
+	errNew := errors.New("new error")
+	_, err := p.Get(func() (*testClient, error) { return nil, errNew })
+	assert.ErrorIs(t, err, errNew)
+	assert.NoError(t, p.Close())
+}
+
+// TestIsConnectionError tests the errors that rebuild the client of the persistent mode
+func TestIsConnectionError(t *testing.T) {
+	connErrors := []error{
+		&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+		fmt.Errorf("read: %w", io.EOF),
+		io.ErrUnexpectedEOF,
+		net.ErrClosed,
+		driver.ErrBadConn,
+		syscall.ECONNRESET,
+		&url.Error{Op: "Get", URL: "http://localhost", Err: &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}},
+		NewExpectedError("failed to establish a connection", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}),
+		context.DeadlineExceeded,
+	}
+	for _, err := range connErrors {
+		assert.True(t, IsConnectionError(err), err)
+	}
+
+	otherErrors := []error{
+		nil,
+		errors.New("NOAUTH Authentication required"),
+		NewExpectedError("table does not exist", nil),
+		&url.Error{Op: "Get", URL: "https://localhost", Err: errors.New("x509: certificate signed by unknown authority")},
+	}
+	for _, err := range otherErrors {
+		assert.False(t, IsConnectionError(err), err)
+	}
+}
@@ -17,19 +17,20 @@ package postgresql
 
 import (
 	"context"
+	"crypto/tls"
 	"database/sql"
 	"fmt"
+	"net"
 	"net/url"
-	"regexp"
+	"strings"
+	"time"
 
-	"wait4x.dev/v3/checker"
+	"github.com/lib/pq"
 
-	// Needed for the PostgreSQL driver
-	_ "github.com/lib/pq"
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 )
 
-var hidePasswordRegexp = regexp.MustCompile(`^(postgres://[^/:]+):[^:@]+@`)
-
 const (
 	expectTableQuery = "SELECT EXISTS (SELECT 1 FROM information_schema.tables WHERE table_name = '%s')"
 )
@@ -41,6 +42,10 @@ type Option func(p *PostgreSQL)
 type PostgreSQL struct {
 	dsn         string
 	expectTable string
+	tlsConfig   *tls.Config
+	dialer      dialer.DialFunc
+	persistent  bool
+	db          *checker.PersistentClient[*sql.DB]
 }
 
 // New creates a new PostgreSQL checker
@@ -54,6 +59,8 @@ func New(dsn string, opts ...Option) checker.Checker {
 		opt(p)
 	}
 
+	p.db = checker.NewPersistentClient(p.persistent, (*sql.DB).Close)
+
 	return p
 }
 
@@ -64,6 +71,27 @@ func WithExpectTable(table string) Option {
 	}
 }
 
+// WithTLSConfig configures TLS for the connection, it takes precedence over the sslmode of the DSN
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(p *PostgreSQL) {
+		p.tlsConfig = tlsConfig
+	}
+}
+
+// WithDialer configures the dialer of the connection, e.g. a proxy dialer
+func WithDialer(d dialer.DialFunc) Option {
+	return func(p *PostgreSQL) {
+		p.dialer = d
+	}
+}
+
+// WithPersistent keeps the connection pool between the checks, it's rebuilt after a connection failure
+func WithPersistent(persistent bool) Option {
+	return func(p *PostgreSQL) {
+		p.persistent = persistent
+	}
+}
+
 // Identity returns the identity of the PostgreSQL checker
 func (p *PostgreSQL) Identity() (string, error) {
 	u, err := url.Parse(p.dsn)
@@ -74,25 +102,43 @@ func (p *PostgreSQL) Identity() (string, error) {
 	return u.Host, nil
 }
 
+// Describe returns the description of the PostgreSQL checker
+func (p *PostgreSQL) Describe() (checker.Description, error) {
+	u, err := url.Parse(p.dsn)
+	if err != nil {
+		return checker.Description{}, fmt.Errorf("can't describe the checker: %w", err)
+	}
+
+	// The display is the address, like the identity, since the query of the DSN may have secrets.
+	desc := checker.DescribeAddress("PostgreSQL", u.Scheme, u.Host)
+	if database := strings.TrimPrefix(u.Path, "/"); database != "" {
+		desc.Labels = map[string]string{"database": database}
+	}
+
+	return desc, nil
+}
+
 // Check checks the PostgreSQL connection
 func (p *PostgreSQL) Check(ctx context.Context) (err error) {
-	db, err := sql.Open("postgres", p.dsn)
+	db, err := p.db.Get(p.open)
 	if err != nil {
 		return err
 	}
 
-	defer func(db *sql.DB) {
-		if dberr := db.Close(); dberr != nil {
+	defer func() {
+		// Only a connection-level failure rebuilds the client, not e.g. an authentication error.
+		broken := checker.IsConnectionError(err)
+		if dberr := p.db.Release(db, broken); dberr != nil {
 			err = dberr
 		}
-	}(db)
+	}()
 
 	err = db.PingContext(ctx)
 	if err != nil {
 		if checker.IsConnectionRefused(err) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the postgresql server", err,
-				"dsn", hidePasswordRegexp.ReplaceAllString(p.dsn, `$1:***@`),
+				"dsn", p.dsn,
 			)
 		}
 
@@ -117,3 +163,73 @@ func (p *PostgreSQL) Check(ctx context.Context) (err error) {
 
 	return nil
 }
+
+// Close closes the connection pool of the persistent mode
+func (p *PostgreSQL) Close() error {
+	return p.db.Close()
+}
+
+// open opens the database with the TLS config and the dialer, if any
+func (p *PostgreSQL) open() (*sql.DB, error) {
+	if p.tlsConfig == nil && p.dialer == nil {
+		return sql.Open("postgres", p.dsn)
+	}
+
+	cfg, err := pq.NewConfig(p.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.tlsConfig != nil {
+		// The driver takes a custom TLS config through a registered sslmode.
+		key := fmt.Sprintf("wait4x-%p", p)
+		if err := pq.RegisterTLSConfig(key, p.tlsConfig); err != nil {
+			return nil, err
+		}
+		cfg.SSLMode = pq.SSLMode("pqgo-" + key)
+
+		// The driver overrides the versions of the TLS config, and its server name unless SNI is disabled.
+		cfg.SSLMinProtocolVersion = sslProtocolVersion(p.tlsConfig.MinVersion)
+		cfg.SSLMaxProtocolVersion = sslProtocolVersion(p.tlsConfig.MaxVersion)
+		if p.tlsConfig.ServerName != "" {
+			cfg.SSLSNI = false
+		}
+	}
+
+	connector, err := pq.NewConnectorConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.dialer != nil {
+		connector.Dialer(pqDialer{p.dialer})
+	}
+
+	return sql.OpenDB(connector), nil
+}
+
+// pqDialer adapts a DialFunc to the dialer of the driver
+type pqDialer struct {
+	dialer.DialFunc
+}
+
+// DialTimeout connects to the address within the timeout
+func (d pqDialer) DialTimeout(network, address string, timeout time.Duration) (net.Conn, error) {
+	return dialer.WithTimeout(d.DialFunc, timeout).Dial(network, address)
+}
+
+// sslProtocolVersion returns the driver's name of the TLS version
+func sslProtocolVersion(version uint16) pq.SSLProtocolVersion {
+	switch version {
+	case tls.VersionTLS10:
+		return pq.SSLProtocolVersionTLS10
+	case tls.VersionTLS11:
+		return pq.SSLProtocolVersionTLS11
+	case tls.VersionTLS12:
+		return pq.SSLProtocolVersionTLS12
+	case tls.VersionTLS13:
+		return pq.SSLProtocolVersionTLS13
+	default:
+		return ""
+	}
+}
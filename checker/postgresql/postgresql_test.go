@@ -25,6 +25,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/log"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 // PostgreSQLSuite is a test suite for PostgreSQL checker
@@ -90,6 +91,26 @@ func (s *PostgreSQLSuite) TestValidAddress() {
 	s.Assert().Nil(chk.Check(ctx))
 }
 
+// TestWithDialer tests the connection of the PostgreSQL checker with a custom dialer
+func (s *PostgreSQLSuite) TestWithDialer() {
+	endpoint, err := s.container.ConnectionString(context.Background())
+	s.Require().NoError(err)
+
+	d := new(test.RecordingDialer)
+	test.AssertDialerUsed(s.T(), d, New(endpoint+"sslmode=disable", WithDialer(d.DialContext)).Check)
+}
+
+// TestCheckNoFDLeak tests that the PostgreSQL checks release their connections
+func (s *PostgreSQLSuite) TestCheckNoFDLeak() {
+	endpoint, err := s.container.ConnectionString(context.Background())
+	s.Require().NoError(err)
+
+	chk := New(endpoint + "sslmode=disable")
+	test.AssertFDsBounded(s.T(), 100, 10, func() {
+		s.Assert().NoError(chk.Check(context.Background()))
+	})
+}
+
 func (s *PostgreSQLSuite) TestTableNotExists() {
 	var expectedError *checker.ExpectedError
 
@@ -20,15 +20,14 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
-	"regexp"
+	"strconv"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 )
 
-var hidePasswordRegexp = regexp.MustCompile(`(amqp://[^/:]+):[^:@]+@`)
-
 // Option configures a RabbitMQ.
 type Option func(r *RabbitMQ)
 
@@ -48,6 +47,8 @@ type RabbitMQ struct {
 	dsn                   string
 	timeout               time.Duration
 	insecureSkipTLSVerify bool
+	tlsConfig             *tls.Config
+	dialer                dialer.DialFunc
 }
 
 // New creates a new RabbitMQ checker
@@ -80,6 +81,20 @@ func WithInsecureSkipTLSVerify(insecureSkipTLSVerify bool) Option {
 	}
 }
 
+// WithTLSConfig configures TLS for amqps:// connections, it takes precedence over WithInsecureSkipTLSVerify
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(r *RabbitMQ) {
+		r.tlsConfig = tlsConfig
+	}
+}
+
+// WithDialer configures the dialer of the connection, e.g. a proxy dialer
+func WithDialer(d dialer.DialFunc) Option {
+	return func(r *RabbitMQ) {
+		r.dialer = d
+	}
+}
+
 // Identity returns the identity of the RabbitMQ checker
 func (r *RabbitMQ) Identity() (string, error) {
 	u, err := amqp.ParseURI(r.dsn)
@@ -90,22 +105,40 @@ func (r *RabbitMQ) Identity() (string, error) {
 	return fmt.Sprintf("%s:%d", u.Host, u.Port), nil
 }
 
+// Describe returns the description of the RabbitMQ checker
+func (r *RabbitMQ) Describe() (checker.Description, error) {
+	u, err := amqp.ParseURI(r.dsn)
+	if err != nil {
+		return checker.Description{}, fmt.Errorf("can't describe the checker: %w", err)
+	}
+
+	desc := checker.DescribeAddress("RabbitMQ", u.Scheme, net.JoinHostPort(u.Host, strconv.Itoa(u.Port)))
+	desc.Labels = map[string]string{"vhost": u.Vhost}
+
+	return desc, nil
+}
+
 // Check checks the RabbitMQ connection
 func (r *RabbitMQ) Check(ctx context.Context) (err error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: r.insecureSkipTLSVerify}
+	if r.tlsConfig != nil {
+		// The client defaults the server name to the host of the URI.
+		tlsConfig = r.tlsConfig.Clone()
+	}
+
+	var netConn net.Conn
 	conn, err := amqp.DialConfig(
 		r.dsn,
 		amqp.Config{
-			Heartbeat: DefaultHeartbeat,
-			Locale:    DefaultLocale,
-			TLSClientConfig: &tls.Config{
-				InsecureSkipVerify: r.insecureSkipTLSVerify,
-			},
+			Heartbeat:       DefaultHeartbeat,
+			Locale:          DefaultLocale,
+			TLSClientConfig: tlsConfig,
 			Dial: func(network, addr string) (net.Conn, error) {
-				d := net.Dialer{Timeout: r.timeout}
-				conn, err := d.DialContext(ctx, network, addr)
+				conn, err := dialer.WithTimeout(r.dialer, r.timeout)(ctx, network, addr)
 				if err != nil {
 					return nil, err
 				}
+				netConn = conn
 
 				// Heartbeating hasn't started yet, don't stall forever on a dead server.
 				// A deadline is set for TLS and AMQP handshaking. After AMQP is established,
@@ -120,10 +153,15 @@ func (r *RabbitMQ) Check(ctx context.Context) (err error) {
 	)
 
 	if err != nil {
+		// The connection isn't always closed when the AMQP handshake fails, e.g. on an unsupported SASL mechanism.
+		if netConn != nil {
+			netConn.Close()
+		}
+
 		if checker.IsConnectionRefused(err) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the rabbitmq server", err,
-				"dsn", hidePasswordRegexp.ReplaceAllString(r.dsn, `$1:***@`),
+				"dsn", r.dsn,
 			)
 		}
 
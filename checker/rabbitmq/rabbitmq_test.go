@@ -25,6 +25,7 @@ import (
 	"github.com/testcontainers/testcontainers-go/log"
 	"github.com/testcontainers/testcontainers-go/modules/rabbitmq"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 // RabbitMQSuite is a test suite for RabbitMQ checker
@@ -87,6 +88,26 @@ func (s *RabbitMQSuite) TestValidConnection() {
 	s.Assert().Nil(chk.Check(ctx))
 }
 
+// TestWithDialer tests the connection of the RabbitMQ checker with a custom dialer
+func (s *RabbitMQSuite) TestWithDialer() {
+	endpoint, err := s.container.AmqpURL(context.Background())
+	s.Require().NoError(err)
+
+	d := new(test.RecordingDialer)
+	test.AssertDialerUsed(s.T(), d, New(endpoint, WithTimeout(5*time.Second), WithDialer(d.DialContext)).Check)
+}
+
+// TestCheckNoFDLeak tests that the RabbitMQ checks release their connections
+func (s *RabbitMQSuite) TestCheckNoFDLeak() {
+	endpoint, err := s.container.AmqpURL(context.Background())
+	s.Require().NoError(err)
+
+	chk := New(endpoint, WithTimeout(5*time.Second))
+	test.AssertFDsBounded(s.T(), 100, 10, func() {
+		s.Assert().NoError(chk.Check(context.Background()))
+	})
+}
+
 // TestRabbitMQ runs the RabbitMQ test suite
 func TestRabbitMQ(t *testing.T) {
 	suite.Run(t, new(RabbitMQSuite))
@@ -17,19 +17,22 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+	"wait4x.dev/v3/checker/tlsconfig"
 )
 
-var hidePasswordRegexp = regexp.MustCompile(`([^/]+//[^/:]+):[^:@]+@`)
-
 // Option configures a Redis.
 type Option func(r *Redis)
 
@@ -38,11 +41,18 @@ const (
 	DefaultConnectionTimeout = 3 * time.Second
 )
 
+// ObservationValue is the observation of the value of the expected key, a string
+const ObservationValue = "value"
+
 // Redis is a Redis checker
 type Redis struct {
-	address   string
-	expectKey string
-	timeout   time.Duration
+	address    string
+	expectKey  string
+	timeout    time.Duration
+	tlsConfig  *tls.Config
+	dialer     dialer.DialFunc
+	persistent bool
+	client     *checker.PersistentClient[*redis.Client]
 }
 
 // New creates a new Redis checker
@@ -57,6 +67,8 @@ func New(address string, opts ...Option) checker.Checker {
 		opt(r)
 	}
 
+	r.client = checker.NewPersistentClient(r.persistent, (*redis.Client).Close)
+
 	return r
 }
 
@@ -74,6 +86,27 @@ func WithExpectKey(key string) Option {
 	}
 }
 
+// WithTLSConfig configures TLS for the connection, even for redis:// addresses
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(r *Redis) {
+		r.tlsConfig = tlsConfig
+	}
+}
+
+// WithDialer configures the dialer of the connection, e.g. a proxy dialer
+func WithDialer(d dialer.DialFunc) Option {
+	return func(r *Redis) {
+		r.dialer = d
+	}
+}
+
+// WithPersistent keeps the client between the checks, it's rebuilt after a connection failure
+func WithPersistent(persistent bool) Option {
+	return func(r *Redis) {
+		r.persistent = persistent
+	}
+}
+
 // Identity returns the identity of the Redis checker
 func (r *Redis) Identity() (string, error) {
 	opts, err := redis.ParseURL(r.address)
@@ -84,15 +117,43 @@ func (r *Redis) Identity() (string, error) {
 	return opts.Addr, nil
 }
 
-// Check checks the Redis connection
-func (r *Redis) Check(ctx context.Context) error {
+// Describe returns the description of the Redis checker
+func (r *Redis) Describe() (checker.Description, error) {
 	opts, err := redis.ParseURL(r.address)
+	if err != nil {
+		return checker.Description{}, fmt.Errorf("can't describe the checker: %w", err)
+	}
+
+	protocol := "redis"
+	if opts.TLSConfig != nil || r.tlsConfig != nil {
+		protocol = "rediss"
+	}
+
+	desc := checker.DescribeAddress("Redis", protocol, opts.Addr)
+	desc.Labels = map[string]string{"db": strconv.Itoa(opts.DB)}
+
+	return desc, nil
+}
+
+// ObservationNames returns the names of the observations of the Redis checker
+func (r *Redis) ObservationNames() []string {
+	return []string{ObservationValue}
+}
+
+// Check checks the Redis connection
+func (r *Redis) Check(ctx context.Context) (err error) {
+	client, err := r.client.Get(r.newClient)
 	if err != nil {
 		return err
 	}
-	opts.DialTimeout = r.timeout
 
-	client := redis.NewClient(opts)
+	defer func() {
+		// Only a connection-level failure rebuilds the client, not e.g. an authentication error.
+		broken := checker.IsConnectionError(err)
+		if cerr := r.client.Release(client, broken); cerr != nil {
+			err = cerr
+		}
+	}()
 
 	// Check Redis connection
 	_, err = client.Ping(ctx).Result()
@@ -100,7 +161,7 @@ func (r *Redis) Check(ctx context.Context) error {
 		if checker.IsConnectionRefused(err) {
 			return checker.NewExpectedError(
 				"failed to establish a connection to the redis server", err,
-				"dsn", hidePasswordRegexp.ReplaceAllString(r.address, `$1:***@`),
+				"dsn", r.address,
 			)
 		}
 
@@ -126,6 +187,8 @@ func (r *Redis) Check(ctx context.Context) error {
 		return err
 	}
 
+	checker.Observe(ctx, ObservationValue, val)
+
 	// The Redis key exists and user doesn't want to match value
 	if !keyHasValue {
 		return nil
@@ -142,3 +205,39 @@ func (r *Redis) Check(ctx context.Context) error {
 		"key", splittedKey[0], "actual", val, "expect", splittedKey[1],
 	)
 }
+
+// Close closes the client of the persistent mode
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
+
+// newClient creates a new client of the address
+func (r *Redis) newClient() (*redis.Client, error) {
+	opts, err := redis.ParseURL(r.address)
+	if err != nil {
+		return nil, err
+	}
+	opts.DialTimeout = r.timeout
+	if r.tlsConfig != nil {
+		opts.TLSConfig = tlsconfig.ForAddress(r.tlsConfig, opts.Addr)
+	}
+	if r.dialer != nil {
+		opts.Dialer = r.dial(opts)
+	}
+
+	return redis.NewClient(opts), nil
+}
+
+// dial returns the dialer of the client, as a custom dialer bypasses its TLS handling
+func (r *Redis) dial(opts *redis.Options) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	dial := dialer.WithTimeout(r.dialer, opts.DialTimeout)
+
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		conn, err := dial(ctx, network, addr)
+		if err != nil || opts.TLSConfig == nil {
+			return conn, err
+		}
+
+		return tls.Client(conn, opts.TLSConfig), nil
+	}
+}
@@ -16,16 +16,22 @@
 package redis
 
 import (
+	"bufio"
 	"context"
+	"net"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/log"
 	redismodule "github.com/testcontainers/testcontainers-go/modules/redis"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 // RedisSuite is a test suite for Redis checker
@@ -88,6 +94,26 @@ func (s *RedisSuite) TestValidAddress() {
 	s.Assert().Nil(chk.Check(ctx))
 }
 
+// TestWithDialer tests the connection of the Redis checker with a custom dialer
+func (s *RedisSuite) TestWithDialer() {
+	endpoint, err := s.container.ConnectionString(context.Background())
+	s.Require().NoError(err)
+
+	d := new(test.RecordingDialer)
+	test.AssertDialerUsed(s.T(), d, New(endpoint, WithDialer(d.DialContext)).Check)
+}
+
+// TestCheckNoFDLeak tests that the Redis checks release their clients
+func (s *RedisSuite) TestCheckNoFDLeak() {
+	endpoint, err := s.container.ConnectionString(context.Background())
+	s.Require().NoError(err)
+
+	chk := New(endpoint)
+	test.AssertFDsBounded(s.T(), 100, 10, func() {
+		s.Assert().NoError(chk.Check(context.Background()))
+	})
+}
+
 // TestKeyExistence tests the key existence of the Redis server
 func (s *RedisSuite) TestKeyExistence() {
 	ctx := context.Background()
@@ -119,3 +145,64 @@ func (s *RedisSuite) TestKeyExistence() {
 func TestRedis(t *testing.T) {
 	suite.Run(t, new(RedisSuite))
 }
+
+// newPongConn returns the client side of an in-memory connection to a server that replies PONG to every command
+func newPongConn() net.Conn {
+	return newReplyConn("+PONG\r\n")
+}
+
+// newReplyConn returns the client side of an in-memory connection to a server that sends the reply to every command
+func newReplyConn(reply string) net.Conn {
+	client, server := net.Pipe()
+	go func() {
+		defer server.Close()
+		r := bufio.NewReader(server)
+		for {
+			// Every command is an array of bulk strings, e.g. *1\r\n$4\r\nping\r\n.
+			header, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(strings.TrimSpace(header[1:]))
+			for range 2 * n {
+				if _, err := r.ReadString('\n'); err != nil {
+					return
+				}
+			}
+			server.Write([]byte(reply))
+		}
+	}()
+
+	return client
+}
+
+// TestPersistent tests that the persistent mode reuses the client between the checks
+func TestPersistent(t *testing.T) {
+	var dials int
+	chk := New("redis://in-memory:6379", WithPersistent(true), WithDialer(func(context.Context, string, string) (net.Conn, error) {
+		dials++
+		return newPongConn(), nil
+	}))
+
+	for range 3 {
+		assert.NoError(t, chk.Check(context.Background()))
+	}
+	assert.Equal(t, 1, dials)
+	assert.NoError(t, chk.(*Redis).Close())
+}
+
+// TestPersistentKeepsClientOnError tests that the persistent mode keeps the client after an error that isn't
+// a connection-level failure
+func TestPersistentKeepsClientOnError(t *testing.T) {
+	var dials int
+	chk := New("redis://in-memory:6379", WithPersistent(true), WithDialer(func(context.Context, string, string) (net.Conn, error) {
+		dials++
+		return newReplyConn("-NOAUTH Authentication required.\r\n"), nil
+	}))
+
+	for range 3 {
+		assert.ErrorContains(t, chk.Check(context.Background()), "NOAUTH")
+	}
+	assert.Equal(t, 1, dials)
+	assert.NoError(t, chk.(*Redis).Close())
+}
@@ -0,0 +1,279 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package srv provides the SRV-record service discovery checker for the Wait4X application.
+package srv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/miekg/dns"
+
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+	dns2 "wait4x.dev/v3/checker/dns"
+)
+
+// Option configures an SRV checker
+type Option func(s *SRV)
+
+// Mode specifies how many of the discovered addresses must be ready
+type Mode string
+
+const (
+	// ModeAny requires at least one of the discovered addresses to be ready
+	ModeAny Mode = "any"
+	// ModeAll requires all the discovered addresses to be ready
+	ModeAll Mode = "all"
+	// ModeQuorum requires the majority of the discovered addresses to be ready
+	ModeQuorum Mode = "quorum"
+
+	// DefaultMode is the default mode
+	DefaultMode = ModeAll
+)
+
+// ErrInvalidMode defines invalid mode error
+var ErrInvalidMode = errors.New("invalid mode provided, expected any, all or quorum")
+
+// ParseMode returns the mode of the given name.
+func ParseMode(mode string) (Mode, error) {
+	switch m := Mode(mode); m {
+	case ModeAny, ModeAll, ModeQuorum:
+		return m, nil
+	default:
+		return "", ErrInvalidMode
+	}
+}
+
+// Factory creates the checker of a discovered "host:port" address
+type Factory func(address string) checker.Checker
+
+// SRV is a checker that discovers its addresses from the SRV records of a service
+type SRV struct {
+	service    string
+	factory    Factory
+	mode       Mode
+	nameserver string
+	dialer     dialer.DialFunc
+
+	mu       sync.Mutex
+	template checker.Checker
+	checkers map[string]checker.Checker
+}
+
+// New creates a new SRV checker for the service, e.g. "_postgres._tcp.db.service.consul",
+// that checks each of the discovered addresses with the checker created by the factory.
+func New(service string, factory Factory, opts ...Option) checker.Checker {
+	s := &SRV{
+		service: service,
+		factory: factory,
+		mode:    DefaultMode,
+	}
+
+	// apply the list of options to SRV
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// WithMode configures how many of the discovered addresses must be ready
+func WithMode(mode Mode) Option {
+	return func(s *SRV) {
+		s.mode = mode
+	}
+}
+
+// WithNameServer overrides the default nameserver for the SRV queries
+func WithNameServer(nameserver string) Option {
+	return func(s *SRV) {
+		s.nameserver = nameserver
+	}
+}
+
+// WithDialer configures the dialer of the connections to the nameserver, the discovered targets use their own
+func WithDialer(d dialer.DialFunc) Option {
+	return func(s *SRV) {
+		s.dialer = d
+	}
+}
+
+// Identity returns the identity of the SRV checker
+func (s *SRV) Identity() (string, error) {
+	return s.service, nil
+}
+
+// Describe returns the description of the SRV checker, which is the one of the checkers of
+// the discovered addresses, with the service as the target.
+func (s *SRV) Describe() (checker.Description, error) {
+	desc := checker.Description{Kind: "SRV"}
+
+	if inner, err := checker.Describe(s.templateChecker()); err == nil {
+		desc = inner
+	}
+
+	desc.Host, desc.Port, desc.Display = s.service, "", s.service
+
+	labels := maps.Clone(desc.Labels)
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels["discovery"], labels["mode"] = "srv", string(s.mode)
+	desc.Labels = labels
+
+	return desc, nil
+}
+
+// ObservationNames returns the names of the observations of the checkers of the discovered targets
+func (s *SRV) ObservationNames() []string {
+	if observer, ok := s.templateChecker().(checker.Observer); ok {
+		return observer.ObservationNames()
+	}
+
+	return nil
+}
+
+// Check discovers the addresses of the service and checks them
+func (s *SRV) Check(ctx context.Context) error {
+	addresses, err := s.lookup(ctx)
+	if err != nil {
+		return err
+	}
+
+	checkers := s.checkersOf(addresses)
+	check := func(ctx context.Context, address string) error {
+		return checkers[address].Check(ctx)
+	}
+
+	switch s.mode {
+	case ModeAll:
+		return checker.CheckAll(ctx, addresses, check)
+	case ModeAny:
+		return checker.CheckAtLeast(ctx, addresses, 1, check)
+	case ModeQuorum:
+		return checker.CheckAtLeast(ctx, addresses, len(addresses)/2+1, check)
+	default:
+		return ErrInvalidMode
+	}
+}
+
+// Close closes the checkers of the discovered addresses
+func (s *SRV) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var errs []error
+	for _, chk := range s.checkers {
+		errs = append(errs, closeChecker(chk))
+	}
+	errs = append(errs, closeChecker(s.template))
+	s.checkers, s.template = nil, nil
+
+	return errors.Join(errs...)
+}
+
+// templateChecker returns the checker of the service that describes the checkers of the discovered addresses
+func (s *SRV) templateChecker() checker.Checker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.template == nil {
+		s.template = s.factory(s.service)
+	}
+
+	return s.template
+}
+
+// checkersOf returns the checkers of the addresses. They're kept between the checks, e.g. for their
+// persistent connections, and the ones of the addresses that are no longer discovered are closed.
+func (s *SRV) checkersOf(addresses []string) map[string]checker.Checker {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	checkers := make(map[string]checker.Checker, len(addresses))
+	for _, address := range addresses {
+		if _, ok := checkers[address]; ok {
+			continue
+		}
+
+		chk, ok := s.checkers[address]
+		if !ok {
+			chk = s.factory(address)
+		}
+		checkers[address] = chk
+	}
+
+	for address, chk := range s.checkers {
+		if _, ok := checkers[address]; !ok {
+			_ = closeChecker(chk)
+		}
+	}
+	s.checkers = checkers
+
+	return checkers
+}
+
+// closeChecker closes the checker when it's a closer
+func closeChecker(chk checker.Checker) error {
+	if closer, ok := chk.(checker.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// lookup resolves the SRV records of the service into "host:port" addresses
+func (s *SRV) lookup(ctx context.Context) ([]string, error) {
+	c := new(dns.Client)
+	c.Timeout = dns2.DefaultTimeout
+
+	m := new(dns.Msg)
+	m.SetQuestion(dns.Fqdn(s.service), dns.TypeSRV)
+	m.RecursionDesired = true
+
+	r, err := dns2.Exchange(ctx, c, m, dns2.RR(s.nameserver), s.dialer)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Rcode == dns.RcodeNameError {
+		return nil, checker.NewExpectedError("the service doesn't exist", nil, "service", s.service)
+	}
+
+	if r.Rcode != dns.RcodeSuccess {
+		return nil, fmt.Errorf("response code is not successful, %d", r.Rcode)
+	}
+
+	var addresses []string
+	for _, answer := range r.Answer {
+		if srv, ok := answer.(*dns.SRV); ok {
+			host := strings.TrimSuffix(srv.Target, ".")
+			addresses = append(addresses, net.JoinHostPort(host, strconv.Itoa(int(srv.Port))))
+		}
+	}
+
+	if len(addresses) == 0 {
+		return nil, checker.NewExpectedError("no SRV record found", nil, "service", s.service)
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package srv
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/suite"
+
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/tcp"
+)
+
+const (
+	// readyService is a service whose addresses are all ready
+	readyService = "_ready._tcp.wait4x.test"
+	// partialService is a service that only one of its addresses is ready
+	partialService = "_partial._tcp.wait4x.test"
+	// missingService is a service that doesn't exist
+	missingService = "_missing._tcp.wait4x.test"
+	// shrinkingService is a service that drops one of its addresses after the first query
+	shrinkingService = "_shrinking._tcp.wait4x.test"
+)
+
+// SRVSuite is a test suite for SRV checker
+type SRVSuite struct {
+	suite.Suite
+
+	// Shared resources for the test suite
+	nameserver string
+	dnsServer  *dns.Server
+	listeners  []net.Listener
+	unusedPort int
+	shrunk     atomic.Bool
+}
+
+// SetupSuite starts the TCP listeners and a DNS server that serves their SRV records
+func (s *SRVSuite) SetupSuite() {
+	var ports []int
+	for range 2 {
+		l, err := net.Listen("tcp", "127.0.0.1:0")
+		s.Require().NoError(err)
+		s.listeners = append(s.listeners, l)
+		ports = append(ports, l.Addr().(*net.TCPAddr).Port)
+
+		go func() {
+			for {
+				conn, err := l.Accept()
+				if err != nil {
+					return // listener closed
+				}
+				conn.Close()
+			}
+		}()
+	}
+
+	unused, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	s.unusedPort = unused.Addr().(*net.TCPAddr).Port
+	s.Require().NoError(unused.Close())
+
+	records := map[string][]int{
+		dns.Fqdn(readyService):     ports,
+		dns.Fqdn(partialService):   {ports[0], s.unusedPort, s.unusedPort},
+		dns.Fqdn(shrinkingService): ports,
+	}
+
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	s.nameserver = pc.LocalAddr().String()
+
+	started := make(chan struct{})
+	s.dnsServer = &dns.Server{
+		PacketConn:        pc,
+		NotifyStartedFunc: func() { close(started) },
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			m := new(dns.Msg)
+			m.SetReply(r)
+
+			name := r.Question[0].Name
+			ports, ok := records[name]
+			if !ok {
+				m.Rcode = dns.RcodeNameError
+			}
+			if name == dns.Fqdn(shrinkingService) && s.shrunk.Swap(true) {
+				ports = ports[:1]
+			}
+
+			for _, port := range ports {
+				rr, err := dns.NewRR(fmt.Sprintf("%s 60 IN SRV 0 0 %d 127.0.0.1.", name, port))
+				s.Require().NoError(err)
+				m.Answer = append(m.Answer, rr)
+			}
+
+			w.WriteMsg(m)
+		}),
+	}
+
+	go s.dnsServer.ActivateAndServe()
+	<-started
+}
+
+// TearDownSuite stops the DNS server and the TCP listeners
+func (s *SRVSuite) TearDownSuite() {
+	s.Require().NoError(s.dnsServer.Shutdown())
+
+	for _, l := range s.listeners {
+		s.Require().NoError(l.Close())
+	}
+}
+
+// factory creates TCP checkers for the discovered addresses
+func (s *SRVSuite) factory(address string) checker.Checker {
+	return tcp.New(address, tcp.WithTimeout(time.Second))
+}
+
+// TestIdentity tests the identity of the SRV checker
+func (s *SRVSuite) TestIdentity() {
+	identity, err := New(readyService, s.factory).Identity()
+	s.Require().NoError(err)
+	s.Equal(readyService, identity)
+}
+
+// TestDescribe tests that the SRV checker describes the checkers of the service
+func (s *SRVSuite) TestDescribe() {
+	desc, err := checker.Describe(New(readyService, s.factory, WithMode(ModeAny)))
+	s.Require().NoError(err)
+	s.Equal("TCP", desc.Kind)
+	s.Equal(readyService, desc.Display)
+	s.Equal(map[string]string{"discovery": "srv", "mode": "any"}, desc.Labels)
+}
+
+// TestCheckAllReady tests a service whose addresses are all ready
+func (s *SRVSuite) TestCheckAllReady() {
+	chk := New(readyService, s.factory, WithNameServer(s.nameserver))
+	s.NoError(chk.Check(context.Background()))
+}
+
+// closingChecker is a checker of a discovered address that counts its closes
+type closingChecker struct {
+	checker.Checker
+	closed *atomic.Int32
+}
+
+// Close counts the close of the checker
+func (c closingChecker) Close() error {
+	c.closed.Add(1)
+	return nil
+}
+
+// TestCheckKeepsCheckers tests that the checkers of the discovered addresses are kept between the checks,
+// and closed with the SRV checker
+func (s *SRVSuite) TestCheckKeepsCheckers() {
+	var created, closed atomic.Int32
+	chk := New(readyService, func(address string) checker.Checker {
+		created.Add(1)
+		return closingChecker{Checker: s.factory(address), closed: &closed}
+	}, WithNameServer(s.nameserver))
+
+	for range 3 {
+		s.NoError(chk.Check(context.Background()))
+	}
+	s.Equal(int32(2), created.Load())
+	s.Zero(closed.Load())
+
+	_, err := checker.Describe(chk)
+	s.Require().NoError(err)
+	s.Equal(int32(3), created.Load())
+
+	s.NoError(chk.(checker.Closer).Close())
+	s.Equal(created.Load(), closed.Load())
+}
+
+// TestCheckClosesDroppedCheckers tests that the checkers of the addresses that are no longer discovered are closed
+func (s *SRVSuite) TestCheckClosesDroppedCheckers() {
+	var created, closed atomic.Int32
+	chk := New(shrinkingService, func(address string) checker.Checker {
+		created.Add(1)
+		return closingChecker{Checker: s.factory(address), closed: &closed}
+	}, WithNameServer(s.nameserver))
+
+	s.NoError(chk.Check(context.Background()))
+	s.Equal(int32(2), created.Load())
+
+	s.NoError(chk.Check(context.Background()))
+	s.Equal(int32(2), created.Load())
+	s.Equal(int32(1), closed.Load())
+
+	s.NoError(chk.(checker.Closer).Close())
+	s.Equal(int32(2), closed.Load())
+}
+
+// TestCheckModes tests the modes with a service that only one of its addresses is ready
+func (s *SRVSuite) TestCheckModes() {
+	var expectedError *checker.ExpectedError
+
+	chk := New(partialService, s.factory, WithNameServer(s.nameserver), WithMode(ModeAny))
+	s.NoError(chk.Check(context.Background()))
+
+	chk = New(partialService, s.factory, WithNameServer(s.nameserver), WithMode(ModeQuorum))
+	err := chk.Check(context.Background())
+	s.ErrorAs(err, &expectedError)
+	s.Contains(err.Error(), "1 of 3 addresses are ready, expected at least 2")
+
+	chk = New(partialService, s.factory, WithNameServer(s.nameserver), WithMode(ModeAll))
+	err = chk.Check(context.Background())
+	s.ErrorAs(err, &expectedError)
+	s.Contains(err.Error(), "2 of 3 addresses aren't ready")
+	s.Contains(err.Error(), net.JoinHostPort("127.0.0.1", strconv.Itoa(s.unusedPort)))
+}
+
+// TestCheckMissingService tests a service that doesn't exist
+func (s *SRVSuite) TestCheckMissingService() {
+	var expectedError *checker.ExpectedError
+
+	chk := New(missingService, s.factory, WithNameServer(s.nameserver))
+	err := chk.Check(context.Background())
+	s.ErrorAs(err, &expectedError)
+	s.Contains(err.Error(), "the service doesn't exist")
+}
+
+// TestCheckInvalidMode tests an invalid mode
+func (s *SRVSuite) TestCheckInvalidMode() {
+	chk := New(readyService, s.factory, WithNameServer(s.nameserver), WithMode("some"))
+	s.ErrorIs(chk.Check(context.Background()), ErrInvalidMode)
+}
+
+// TestSRVSuite runs the test suite
+func TestSRVSuite(t *testing.T) {
+	suite.Run(t, new(SRVSuite))
+}
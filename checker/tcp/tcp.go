@@ -17,11 +17,14 @@ package tcp
 
 import (
 	"context"
+	"crypto/tls"
 	"net"
 	"os"
 	"time"
 
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
+	"wait4x.dev/v3/checker/tlsconfig"
 )
 
 // Option configures a TCP checker
@@ -34,8 +37,12 @@ const (
 
 // TCP is a TCP checker
 type TCP struct {
-	address string
-	timeout time.Duration
+	address      string
+	timeout      time.Duration
+	allAddresses bool
+	ipFamily     checker.IPFamily
+	tlsConfig    *tls.Config
+	dialer       dialer.DialFunc
 }
 
 // New creates a new TCP checker
@@ -60,16 +67,66 @@ func WithTimeout(timeout time.Duration) Option {
 	}
 }
 
+// WithAllAddresses configures checking every IP address the host resolves to, instead of the first reachable one
+func WithAllAddresses(allAddresses bool) Option {
+	return func(t *TCP) {
+		t.allAddresses = allAddresses
+	}
+}
+
+// WithIPFamily restricts the connections to an IP family
+func WithIPFamily(family checker.IPFamily) Option {
+	return func(t *TCP) {
+		t.ipFamily = family
+	}
+}
+
+// WithTLSConfig configures a TLS handshake over the connection, the server name defaults to the address host
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(t *TCP) {
+		t.tlsConfig = tlsConfig
+	}
+}
+
+// WithDialer configures the dialer of the connections, e.g. a proxy dialer
+func WithDialer(d dialer.DialFunc) Option {
+	return func(t *TCP) {
+		t.dialer = d
+	}
+}
+
 // Identity returns the identity of the TCP checker
 func (t *TCP) Identity() (string, error) {
 	return t.address, nil
 }
 
+// Describe returns the description of the TCP checker
+func (t *TCP) Describe() (checker.Description, error) {
+	protocol := "tcp"
+	if t.tlsConfig != nil {
+		protocol = "tls"
+	}
+
+	return checker.DescribeAddress("TCP", protocol, t.address), nil
+}
+
 // Check checks the TCP connection
 func (t *TCP) Check(ctx context.Context) error {
-	d := net.Dialer{Timeout: t.timeout}
+	if !t.allAddresses {
+		return t.dial(ctx, t.address)
+	}
+
+	addresses, err := checker.LookupAddresses(ctx, net.DefaultResolver, t.address, t.ipFamily)
+	if err != nil {
+		return err
+	}
+
+	return checker.CheckAll(ctx, addresses, t.dial)
+}
 
-	_, err := d.DialContext(ctx, "tcp", t.address)
+// dial checks the TCP connection to the address
+func (t *TCP) dial(ctx context.Context, address string) error {
+	conn, err := dialer.WithTimeout(t.dialer, t.timeout)(ctx, t.ipFamily.Network("tcp"), address)
 	if err != nil {
 		if os.IsTimeout(err) {
 			return checker.NewExpectedError("timed out while making a tcp call", err, "timeout", t.timeout)
@@ -80,5 +137,31 @@ func (t *TCP) Check(ctx context.Context) error {
 		return err
 	}
 
+	// The connection is only dialed to check the port, an error closing it doesn't fail the check,
+	// e.g. the EOF of a tunnel that the target has already closed.
+	defer conn.Close()
+
+	if t.tlsConfig != nil {
+		return t.handshake(ctx, conn)
+	}
+
+	return nil
+}
+
+// handshake checks the TLS handshake over the connection
+func (t *TCP) handshake(ctx context.Context, conn net.Conn) error {
+	// The dialed address may be one of the resolved IPs, so the server name comes from the checker address.
+	cfg := tlsconfig.ForAddress(t.tlsConfig, t.address)
+
+	tlsConn := tls.Client(conn, cfg)
+	defer tlsConn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return checker.NewExpectedError("failed to establish a tls connection", err, "server_name", cfg.ServerName)
+	}
+
 	return nil
 }
@@ -17,15 +17,19 @@ package tcp
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/internal/test"
 )
 
 // TCPSuite is a test suite for TCP checker
@@ -142,6 +146,17 @@ func (s *TCPSuite) TestIdentity() {
 	s.Equal(address, identity)
 }
 
+// TestDescribe tests the Describe method
+func (s *TCPSuite) TestDescribe() {
+	desc, err := New("127.0.0.1:8080").(checker.Describer).Describe()
+	s.Require().NoError(err)
+	s.Equal(checker.Description{Kind: "TCP", Host: "127.0.0.1", Port: "8080", Protocol: "tcp", Display: "127.0.0.1:8080"}, desc)
+
+	desc, err = New("127.0.0.1:8443", WithTLSConfig(&tls.Config{})).(checker.Describer).Describe()
+	s.Require().NoError(err)
+	s.Equal("tls", desc.Protocol)
+}
+
 // TestCheckSuccessful tests successful TCP connection
 func (s *TCPSuite) TestCheckSuccessful() {
 	tc := New(s.listener.Addr().String())
@@ -175,6 +190,60 @@ func (s *TCPSuite) TestCheckInvalidAddress() {
 	s.True(errors.As(err, &expectedErr))
 }
 
+// TestCheckAllAddresses tests checking every address of a host
+func (s *TCPSuite) TestCheckAllAddresses() {
+	tc := New(fmt.Sprintf("localhost:%d", s.port), WithAllAddresses(true), WithIPFamily(checker.IPFamilyIPv4))
+	s.NoError(tc.Check(context.Background()))
+}
+
+// TestCheckAllAddressesFailed tests the report of the failed addresses
+func (s *TCPSuite) TestCheckAllAddressesFailed() {
+	address := fmt.Sprintf("127.0.0.1:%d", s.unusedPort)
+	tc := New(address, WithAllAddresses(true), WithTimeout(500*time.Millisecond))
+	err := tc.Check(context.Background())
+
+	var expectedErr *checker.ExpectedError
+	if s.True(errors.As(err, &expectedErr)) {
+		s.Contains(expectedErr.Error(), "1 of 1 addresses aren't ready")
+		s.Equal([]any{"ready", []string(nil), "failed", []string{address}}, expectedErr.Details())
+	}
+}
+
+// TestCheckIPFamilyMismatch tests an address that doesn't belong to the IP family
+func (s *TCPSuite) TestCheckIPFamilyMismatch() {
+	tc := New(s.listener.Addr().String(), WithAllAddresses(true), WithIPFamily(checker.IPFamilyIPv6))
+	err := tc.Check(context.Background())
+
+	var expectedErr *checker.ExpectedError
+	if s.True(errors.As(err, &expectedErr)) {
+		s.Contains(expectedErr.Error(), "failed to resolve the host")
+	}
+
+	tc = New(s.listener.Addr().String(), WithIPFamily(checker.IPFamilyIPv6))
+	s.Error(tc.Check(context.Background()))
+}
+
+// TestCheckTLS tests the TLS handshake over the connection
+func (s *TCPSuite) TestCheckTLS() {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	tc := New(server.Listener.Addr().String(), WithTLSConfig(tlsConfig))
+	s.NoError(tc.Check(context.Background()))
+
+	var expectedErr *checker.ExpectedError
+
+	tc = New(server.Listener.Addr().String(), WithTLSConfig(&tls.Config{}))
+	err := tc.Check(context.Background())
+	s.ErrorAs(err, &expectedErr)
+	s.Contains(err.Error(), "failed to establish a tls connection")
+
+	tc = New(s.listener.Addr().String(), WithTLSConfig(tlsConfig))
+	s.ErrorAs(tc.Check(context.Background()), &expectedErr)
+}
+
 // TestCheckTimeout tests timeout behavior
 func (s *TCPSuite) TestCheckTimeout() {
 	// Use a black-hole IP that will cause timeout
@@ -322,6 +391,46 @@ func (s *TCPSuite) getCancelledContext() context.Context {
 	return ctx
 }
 
+// TestCheckWithDialer tests the TCP checker with a custom dialer
+func (s *TCPSuite) TestCheckWithDialer() {
+	var dialed string
+	tc := New("in-memory:80", WithDialer(func(_ context.Context, network, address string) (net.Conn, error) {
+		dialed = network + "/" + address
+		client, server := net.Pipe()
+		server.Close()
+		return client, nil
+	}))
+	s.NoError(tc.Check(context.Background()))
+	s.Equal("tcp/in-memory:80", dialed)
+
+	errDial := errors.New("dial error")
+	tc = New("in-memory:80", WithDialer(func(context.Context, string, string) (net.Conn, error) {
+		return nil, errDial
+	}))
+	s.ErrorIs(tc.Check(context.Background()), errDial)
+}
+
+// TestCheckNoFDLeak tests that the checks release their connections
+func (s *TCPSuite) TestCheckNoFDLeak() {
+	tc := New(s.listener.Addr().String())
+	test.AssertFDsBounded(s.T(), 200, 10, func() {
+		s.NoError(tc.Check(context.Background()))
+	})
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	tc = New(server.Listener.Addr().String(), WithTLSConfig(server.Client().Transport.(*http.Transport).TLSClientConfig))
+	test.AssertFDsBounded(s.T(), 200, 10, func() {
+		s.NoError(tc.Check(context.Background()))
+	})
+
+	tc = New(net.JoinHostPort("127.0.0.1", strconv.Itoa(s.unusedPort)))
+	test.AssertFDsBounded(s.T(), 200, 10, func() {
+		s.Error(tc.Check(context.Background()))
+	})
+}
+
 // TestTCPSuite runs the test suite
 func TestTCPSuite(t *testing.T) {
 	suite.Run(t, new(TCPSuite))
@@ -32,6 +32,7 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/dialer"
 )
 
 // Option configures a Temporal checker
@@ -57,6 +58,9 @@ const (
 	CheckModeWorker = "worker"
 )
 
+// ObservationPollerIdentities is the observation of the identities of the pollers of the task queue, a []string
+const ObservationPollerIdentities = "poller_identities"
+
 var (
 	// ErrInvalidMode defines invalid mode error
 	ErrInvalidMode = errors.New("invalid checkMode provided")
@@ -75,7 +79,11 @@ type Temporal struct {
 	taskQueue                 string
 	insecureTransport         bool
 	insecureSkipTLSVerify     bool
+	tlsConfig                 *tls.Config
+	dialer                    dialer.DialFunc
 	expectWorkerIdentityRegex string
+	persistent                bool
+	conn                      *checker.PersistentClient[*grpc.ClientConn]
 }
 
 // New creates a new Temporal checker
@@ -93,6 +101,8 @@ func New(checkMode CheckMode, target string, opts ...Option) checker.Checker {
 		opt(t)
 	}
 
+	t.conn = checker.NewPersistentClient(t.persistent, (*grpc.ClientConn).Close)
+
 	return t
 }
 
@@ -117,6 +127,27 @@ func WithTaskQueue(taskQueue string) Option {
 	}
 }
 
+// WithTLSConfig configures the transport security, it takes precedence over WithInsecureSkipTLSVerify
+func WithTLSConfig(tlsConfig *tls.Config) Option {
+	return func(t *Temporal) {
+		t.tlsConfig = tlsConfig
+	}
+}
+
+// WithDialer configures the dialer of the GRPC connection, e.g. a proxy dialer
+func WithDialer(d dialer.DialFunc) Option {
+	return func(t *Temporal) {
+		t.dialer = d
+	}
+}
+
+// WithPersistent keeps the GRPC connection between the checks, it reconnects by itself after a failure
+func WithPersistent(persistent bool) Option {
+	return func(t *Temporal) {
+		t.persistent = persistent
+	}
+}
+
 // WithInsecureTransport disables transport security
 func WithInsecureTransport(insecureTransport bool) Option {
 	return func(t *Temporal) {
@@ -143,14 +174,33 @@ func (t *Temporal) Identity() (string, error) {
 	return t.target, nil
 }
 
+// Describe returns the description of the Temporal checker
+func (t *Temporal) Describe() (checker.Description, error) {
+	desc := checker.DescribeAddress("Temporal", "grpc", t.target)
+	desc.Labels = map[string]string{"mode": string(t.checkMode)}
+	if t.namespace != "" {
+		desc.Labels["namespace"] = t.namespace
+	}
+	if t.taskQueue != "" {
+		desc.Labels["task_queue"] = t.taskQueue
+	}
+
+	return desc, nil
+}
+
+// ObservationNames returns the names of the observations of the Temporal checker
+func (t *Temporal) ObservationNames() []string {
+	return []string{ObservationPollerIdentities}
+}
+
 // Check checks the Temporal connection
 func (t *Temporal) Check(ctx context.Context) (err error) {
-	conn, err := t.getGRPCConn()
+	conn, err := t.conn.Get(t.getGRPCConn)
 	if err != nil {
 		return err
 	}
 	defer func(conn *grpc.ClientConn) {
-		if connErr := conn.Close(); connErr != nil {
+		if connErr := t.conn.Release(conn, false); connErr != nil {
 			err = connErr
 		}
 	}(conn)
@@ -174,17 +224,23 @@ func (t *Temporal) Check(ctx context.Context) (err error) {
 	}
 }
 
+// Close closes the GRPC connection of the persistent mode
+func (t *Temporal) Close() error {
+	return t.conn.Close()
+}
+
 // getGRPCConn gets a GRPC connection
 func (t *Temporal) getGRPCConn() (*grpc.ClientConn, error) {
 	opts := []grpc.DialOption{
 		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
-			d := net.Dialer{Timeout: t.timeout}
-			return d.DialContext(ctx, "tcp", addr)
+			return dialer.WithTimeout(t.dialer, t.timeout)(ctx, "tcp", addr)
 		}),
 	}
 
 	if t.insecureTransport {
 		opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	} else if t.tlsConfig != nil {
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(t.tlsConfig)))
 	} else {
 		opts = append(
 			opts,
@@ -248,6 +304,12 @@ func (t *Temporal) checkWorker(ctx context.Context, conn grpc.ClientConnInterfac
 		)
 	}
 
+	identities := make([]string, 0, len(resp.Pollers))
+	for _, poller := range resp.Pollers {
+		identities = append(identities, poller.Identity)
+	}
+	checker.Observe(ctx, ObservationPollerIdentities, identities)
+
 	if len(resp.Pollers) == 0 {
 		return checker.NewExpectedError("no worker (poller) registered", nil)
 	}
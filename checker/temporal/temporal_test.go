@@ -0,0 +1,53 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package temporal
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"wait4x.dev/v3/internal/test"
+)
+
+// TestCheckNoFDLeak tests that the Temporal server checks release their gRPC connections
+func TestCheckNoFDLeak(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("temporal.api.workflowservice.v1.WorkflowService", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	server := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	chk := New(CheckModeServer, listener.Addr().String(), WithInsecureTransport(true))
+	test.AssertFDsBounded(t, 200, 10, func() {
+		assert.NoError(t, chk.Check(context.Background()))
+	})
+
+	chk = New(CheckModeServer, listener.Addr().String(), WithInsecureTransport(true), WithPersistent(true))
+	test.AssertFDsBounded(t, 200, 10, func() {
+		assert.NoError(t, chk.Check(context.Background()))
+	})
+	assert.NoError(t, chk.(*Temporal).Close())
+}
@@ -0,0 +1,244 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tlsconfig builds the TLS configuration shared by the network checkers of the Wait4X application.
+package tlsconfig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// pinPrefix is the optional prefix of a pinned SPKI hash, as used by HPKP and curl.
+const pinPrefix = "sha256/"
+
+var (
+	// ErrCertKeyMismatch defines the error of a client certificate without its key or vice versa
+	ErrCertKeyMismatch = errors.New("both cert file and key file should be assigned values, not just one of them")
+	// ErrPinMismatch defines the error of a server whose certificates don't match any of the pinned public keys
+	ErrPinMismatch = errors.New("none of the server certificates matches the pinned public keys")
+)
+
+// Option configures a TLS config
+type Option func(o *options)
+
+// options holds the settings that a TLS config is built from
+type options struct {
+	caFile             string
+	certFile           string
+	keyFile            string
+	serverName         string
+	insecureSkipVerify bool
+	minVersion         uint16
+	maxVersion         uint16
+	cipherSuites       []uint16
+	pins               []string
+}
+
+// WithCAFile configures the PEM bundle of the CAs that verify the server certificates, instead of the system ones
+func WithCAFile(caFile string) Option {
+	return func(o *options) {
+		o.caFile = caFile
+	}
+}
+
+// WithCertFile configures the PEM file of the client certificate, it needs WithKeyFile too
+func WithCertFile(certFile string) Option {
+	return func(o *options) {
+		o.certFile = certFile
+	}
+}
+
+// WithKeyFile configures the PEM file of the client certificate key, it needs WithCertFile too
+func WithKeyFile(keyFile string) Option {
+	return func(o *options) {
+		o.keyFile = keyFile
+	}
+}
+
+// WithServerName overrides the server name that is sent through SNI and verified against the server certificate
+func WithServerName(serverName string) Option {
+	return func(o *options) {
+		o.serverName = serverName
+	}
+}
+
+// WithInsecureSkipVerify configures skipping the verification of the server certificate chain and name
+func WithInsecureSkipVerify(insecureSkipVerify bool) Option {
+	return func(o *options) {
+		o.insecureSkipVerify = insecureSkipVerify
+	}
+}
+
+// WithMinVersion configures the minimum TLS version, e.g. tls.VersionTLS12
+func WithMinVersion(version uint16) Option {
+	return func(o *options) {
+		o.minVersion = version
+	}
+}
+
+// WithMaxVersion configures the maximum TLS version, e.g. tls.VersionTLS13
+func WithMaxVersion(version uint16) Option {
+	return func(o *options) {
+		o.maxVersion = version
+	}
+}
+
+// WithCipherSuites configures the enabled TLS 1.0-1.2 cipher suites, TLS 1.3 ones aren't configurable
+func WithCipherSuites(cipherSuites []uint16) Option {
+	return func(o *options) {
+		o.cipherSuites = cipherSuites
+	}
+}
+
+// WithPinnedSPKI configures the base64 SHA-256 hashes of the public keys (SPKI) that the server
+// certificate chain must contain one of, e.g. "sha256/AAAA...=". The pins are checked even
+// when the verification is skipped.
+func WithPinnedSPKI(pins []string) Option {
+	return func(o *options) {
+		o.pins = pins
+	}
+}
+
+// New builds a TLS config from the options
+func New(opts ...Option) (*tls.Config, error) {
+	o := &options{}
+
+	// apply the list of options
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cfg := &tls.Config{
+		ServerName:         o.serverName,
+		InsecureSkipVerify: o.insecureSkipVerify,
+		MinVersion:         o.minVersion,
+		MaxVersion:         o.maxVersion,
+		CipherSuites:       o.cipherSuites,
+	}
+
+	// Cert and key files.
+	if (o.certFile == "") != (o.keyFile == "") {
+		return nil, ErrCertKeyMismatch
+	}
+
+	if o.certFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.certFile, o.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't load the client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	// CA file.
+	if o.caFile != "" {
+		ca, err := os.ReadFile(o.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't read the CA file: %w", err)
+		}
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("can't append the CA file")
+		}
+		cfg.RootCAs = certPool
+	}
+
+	// Pinned public keys.
+	if len(o.pins) > 0 {
+		pins := make([][]byte, len(o.pins))
+		for i, pin := range o.pins {
+			hash, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pin, pinPrefix))
+			if err != nil || len(hash) != sha256.Size {
+				return nil, fmt.Errorf("invalid pinned public key %q, expected a base64 SHA-256 hash", pin)
+			}
+			pins[i] = hash
+		}
+
+		cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+			for _, cert := range cs.PeerCertificates {
+				hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+				for _, pin := range pins {
+					if bytes.Equal(hash[:], pin) {
+						return nil
+					}
+				}
+			}
+
+			return ErrPinMismatch
+		}
+	}
+
+	return cfg, nil
+}
+
+// ForAddress returns a clone of the TLS config whose server name defaults to the host of the "host:port" address
+func ForAddress(cfg *tls.Config, address string) *tls.Config {
+	cfg = cfg.Clone()
+	if cfg.ServerName == "" {
+		if host, _, err := net.SplitHostPort(address); err == nil {
+			cfg.ServerName = host
+		}
+	}
+
+	return cfg
+}
+
+// SPKIHash returns the pin of the certificate public key in the format of WithPinnedSPKI
+func SPKIHash(cert *x509.Certificate) string {
+	hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return pinPrefix + base64.StdEncoding.EncodeToString(hash[:])
+}
+
+// ParseVersion returns the TLS version of the given name, e.g. "1.2" or "TLS1.2"
+func ParseVersion(version string) (uint16, error) {
+	switch strings.TrimPrefix(strings.ToUpper(version), "TLS") {
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("invalid TLS version %q, expected 1.0, 1.1, 1.2 or 1.3", version)
+	}
+}
+
+// ParseCipherSuites returns the IDs of the cipher suites of the given names, e.g. "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"
+func ParseCipherSuites(names []string) ([]uint16, error) {
+	suites := make(map[string]uint16)
+	for _, suite := range append(tls.CipherSuites(), tls.InsecureCipherSuites()...) {
+		suites[suite.Name] = suite.ID
+	}
+
+	ids := make([]uint16, len(names))
+	for i, name := range names {
+		id, ok := suites[strings.ToUpper(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown cipher suite %q", name)
+		}
+		ids[i] = id
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,124 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handshake connects to the server with the TLS config
+func handshake(server *httptest.Server, cfg *tls.Config) error {
+	conn, err := tls.Dial("tcp", server.Listener.Addr().String(), cfg)
+	if err != nil {
+		return err
+	}
+
+	return conn.Close()
+}
+
+// TestCAFile tests verifying the server with a CA file
+func TestCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, ca, 0o600))
+
+	cfg, err := New(WithServerName("example.com"))
+	require.NoError(t, err)
+	assert.Error(t, handshake(server, cfg))
+
+	cfg, err = New(WithCAFile(caFile), WithServerName("example.com"))
+	require.NoError(t, err)
+	assert.NoError(t, handshake(server, cfg))
+
+	_, err = New(WithCAFile(filepath.Join(t.TempDir(), "missing.pem")))
+	assert.ErrorContains(t, err, "can't read the CA file")
+}
+
+// TestCertKeyMismatch tests a client certificate without its key
+func TestCertKeyMismatch(t *testing.T) {
+	_, err := New(WithCertFile("cert.pem"))
+	assert.ErrorIs(t, err, ErrCertKeyMismatch)
+
+	_, err = New(WithKeyFile("key.pem"))
+	assert.ErrorIs(t, err, ErrCertKeyMismatch)
+}
+
+// TestPinnedSPKI tests pinning the server public key
+func TestPinnedSPKI(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	pin := SPKIHash(server.Certificate())
+
+	cfg, err := New(WithInsecureSkipVerify(true), WithPinnedSPKI([]string{pin}))
+	require.NoError(t, err)
+	assert.NoError(t, handshake(server, cfg))
+
+	cfg, err = New(WithInsecureSkipVerify(true), WithPinnedSPKI([]string{"sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU="}))
+	require.NoError(t, err)
+	assert.ErrorIs(t, handshake(server, cfg), ErrPinMismatch)
+
+	_, err = New(WithPinnedSPKI([]string{"sha256/invalid"}))
+	assert.ErrorContains(t, err, "invalid pinned public key")
+}
+
+// TestVersions tests restricting the TLS versions
+func TestVersions(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	cfg, err := New(WithInsecureSkipVerify(true), WithMinVersion(tls.VersionTLS13))
+	require.NoError(t, err)
+	assert.NoError(t, handshake(server, cfg))
+
+	server.TLS.MaxVersion = tls.VersionTLS12
+	assert.Error(t, handshake(server, cfg))
+}
+
+// TestParseVersion tests parsing the TLS versions
+func TestParseVersion(t *testing.T) {
+	version, err := ParseVersion("1.2")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS12), version)
+
+	version, err = ParseVersion("tls1.3")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), version)
+
+	_, err = ParseVersion("1.4")
+	assert.ErrorContains(t, err, "invalid TLS version")
+}
+
+// TestParseCipherSuites tests parsing the cipher suite names
+func TestParseCipherSuites(t *testing.T) {
+	suites, err := ParseCipherSuites([]string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256", "tls_rsa_with_aes_128_cbc_sha"})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_RSA_WITH_AES_128_CBC_SHA}, suites)
+
+	_, err = ParseCipherSuites([]string{"TLS_UNKNOWN"})
+	assert.ErrorContains(t, err, "unknown cipher suite")
+}
@@ -15,7 +15,9 @@
 package checker
 
 import (
+	"database/sql/driver"
 	"errors"
+	"io"
 	"net"
 	"syscall"
 )
@@ -39,3 +41,25 @@ func IsConnectionRefused(err error) bool {
 
 	return false
 }
+
+// IsConnectionError attempts to determine if the given error was caused by a connection-level failure, e.g. a
+// network error, a timeout or a closed connection, rather than e.g. an authentication error or an unmet expectation.
+func IsConnectionError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	for _, connErr := range []error{io.EOF, io.ErrUnexpectedEOF, net.ErrClosed, driver.ErrBadConn, syscall.ECONNRESET, syscall.EPIPE} {
+		if errors.Is(err, connErr) {
+			return true
+		}
+	}
+
+	return false
+}
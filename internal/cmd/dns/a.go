@@ -22,6 +22,7 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/secretref"
 
 	dns "wait4x.dev/v3/checker/dns/a"
 	"wait4x.dev/v3/waiter"
@@ -77,15 +78,27 @@ func runA(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --expect-ip flag: %w", err)
 	}
 
+	address, err := secretref.Resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := newDialer(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("failed to get logger from context: %w", err)
 	}
 
 	dc := dns.New(
-		args[0],
+		address,
 		dns.WithExpectedIPV4s(expectIPs),
 		dns.WithNameServer(nameserver),
+		dns.WithDialer(dialer),
 	)
 
 	return waiter.WaitContext(cmd.Context(),
@@ -94,5 +107,6 @@ func runA(cmd *cobra.Command, args []string) error {
 		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
 		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
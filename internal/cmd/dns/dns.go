@@ -16,7 +16,14 @@
 package dns
 
 import (
+	"context"
+	"net"
+	"strings"
+
 	"github.com/spf13/cobra"
+
+	"wait4x.dev/v3/checker/dialer"
+	"wait4x.dev/v3/internal/dialflags"
 )
 
 // NewDNSCommand creates a new DNS command
@@ -28,6 +35,7 @@ func NewDNSCommand() *cobra.Command {
 	}
 
 	command.PersistentFlags().StringP("nameserver", "n", "", "Nameserver to use for the DNS query (e.g. 8.8.8.8:53)")
+	dialflags.Add(command.PersistentFlags())
 
 	command.AddCommand(NewACommand())
 	command.AddCommand(NewAAAACommand())
@@ -38,3 +46,20 @@ func NewDNSCommand() *cobra.Command {
 
 	return command
 }
+
+// newDialer returns the dialer of the flags and a function that closes it. The proxies and the SSH jump
+// host only carry TCP, so the queries go over TCP when the connections aren't direct.
+func newDialer(cmd *cobra.Command) (dialer.DialFunc, func(), error) {
+	dial, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil || dial == nil {
+		return dial, closeDialer, err
+	}
+
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		if suffix, ok := strings.CutPrefix(network, "udp"); ok {
+			network = "tcp" + suffix
+		}
+
+		return dial(ctx, network, address)
+	}, closeDialer, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dns
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/miekg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/test"
+)
+
+// TestDNSCommandWithSSHJump tests that the DNS queries go over TCP through an SSH jump host
+func TestDNSCommandWithSSHJump(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := &dns.Server{Listener: listener, Handler: dns.HandlerFunc(func(w dns.ResponseWriter, m *dns.Msg) {
+		r := new(dns.Msg).SetReply(m)
+		if m.Question[0].Qtype == dns.TypeA {
+			r.Answer = append(r.Answer, &dns.A{
+				Hdr: dns.RR_Header{Name: m.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+				A:   net.ParseIP("192.0.2.1"),
+			})
+		}
+		_ = w.WriteMsg(r)
+	})}
+	go func() { _ = server.ActivateAndServe() }()
+	defer server.Shutdown()
+
+	sshServer, err := test.NewSSHServer()
+	require.NoError(t, err)
+	defer sshServer.Close()
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "id_ed25519")
+	require.NoError(t, os.WriteFile(keyFile, sshServer.ClientKeyPEM, 0o600))
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	require.NoError(t, os.WriteFile(knownHostsFile, sshServer.KnownHosts, 0o600))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmd := NewDNSCommand()
+	cmd.SetArgs([]string{
+		"A", "wait4x.example", "--expect-ip", "192.0.2.1",
+		"-n", listener.Addr().String(),
+		"--ssh-jump", "wait4x@" + sshServer.Addr,
+		"--ssh-key-file", keyFile,
+		"--ssh-known-hosts", knownHostsFile,
+	})
+
+	ctx = contextutil.WithInterval(logr.NewContext(ctx, logr.Discard()), time.Second)
+	assert.NoError(t, cmd.ExecuteContext(ctx))
+	assert.Equal(t, 1, sshServer.Connections())
+}
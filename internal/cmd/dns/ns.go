@@ -23,6 +23,7 @@ import (
 	"github.com/spf13/cobra"
 	dns "wait4x.dev/v3/checker/dns/ns"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/secretref"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -78,15 +79,27 @@ func runNS(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --expect-nameserver flag: %w", err)
 	}
 
+	address, err := secretref.Resolve(args[0])
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := newDialer(cmd)
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("failed to get logger from context: %w", err)
 	}
 
 	dc := dns.New(
-		args[0],
+		address,
 		dns.WithExpectedNameservers(expectNameservers),
 		dns.WithNameServer(nameserver),
+		dns.WithDialer(dialer),
 	)
 
 	return waiter.WaitContext(cmd.Context(),
@@ -95,5 +108,6 @@ func runNS(cmd *cobra.Command, args []string) error {
 		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
 		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
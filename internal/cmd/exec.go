@@ -104,5 +104,6 @@ func runExec(cmd *cobra.Command, args []string) error {
 			contextutil.GetBackoffExponentialMaxInterval(cmd.Context()),
 		),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
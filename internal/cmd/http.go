@@ -23,14 +23,20 @@ import (
 	nethttp "net/http"
 	"net/textproto"
 	"net/url"
+	"os"
 	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/oauth2/clientcredentials"
 
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/http"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/secretref"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -39,25 +45,34 @@ func NewHTTPCommand() *cobra.Command {
 	httpCommand := &cobra.Command{
 		Use:   "http ADDRESS... [flags] [-- command [args...]]",
 		Short: "Check HTTP connection",
-		Args: func(_ *cobra.Command, args []string) error {
-			if len(args) < 1 {
+		Args: func(cmd *cobra.Command, args []string) error {
+			if !hasTargets(cmd, args) {
 				return errors.New("ADDRESS is required argument for the http command")
 			}
 
-			_, err := url.Parse(args[0])
-			if err != nil {
-				return err
-			}
-
 			return nil
 		},
 		Example: `
   # If you want checking just http connection
   wait4x http https://ifconfig.co
 
+  # If you want checking the addresses listed in a file (one per line)
+  wait4x http --targets-file ./urls.txt
+
+  # If you want checking several nodes, brace lists and numeric ranges are expanded
+  wait4x http 'http://node-[1-3].local:8080/health'
+
   # If you want checking http connection and expect specify http status code
   wait4x http https://ifconfig.co --expect-status-code 200
 
+  # If you want checking http connection and expect a list, class or range of status codes
+  wait4x http https://ifconfig.co --expect-status-code 200,204
+  wait4x http https://ifconfig.co --expect-status-code 2xx,!204
+  wait4x http https://ifconfig.co --expect-status-code '!5xx'
+
+  # If you want the response headers within 200ms for 3 consecutive checks
+  wait4x http https://ifconfig.co --expect-response-time 200ms --expect-response-time-consecutive 3
+
   # If you want to check a http response header
   # NOTE: the value in the expected header is regex.
   # Sample response header: Authorization Token 1234ABCD
@@ -76,9 +91,19 @@ func NewHTTPCommand() *cobra.Command {
   wait4x http https://ifconfig.co/json --expect-body-json "user_agent.product"
   To know more about JSON syntax https://github.com/tidwall/gjson/blob/master/SYNTAX.md
 
+  # Body JSON values, compared with ==, !=, <, <=, >, >=, =~ (regex), in (list) or is (type):
+  wait4x http https://api.example.com/health --expect-body-json 'status == "UP"' --expect-body-json 'replicas_ready >= 3'
+  wait4x http https://api.example.com/health --expect-body-json 'env in ["prod","staging"]' --expect-body-json 'checks is array'
+
+  # Body JSON schema:
+  wait4x http https://api.example.com/v2/status --expect-body-json-schema ./status.schema.json
+
   # Body XPath:
   wait4x http https://www.kernel.org/ --expect-body-xpath "//*[@id="tux-gear"]"
 
+  # Several expectations, all of them must match:
+  wait4x http https://ifconfig.co/json --expect-body-json "ip" --expect-body-json "country" --expect-body-regex '"asn"'
+
   # Request headers:
   wait4x http https://ifconfig.co --request-header "Content-Type: application/json" --request-header "Authorization: Token 123"
 
@@ -88,70 +113,173 @@ func NewHTTPCommand() *cobra.Command {
   # Post request (application/json):
   wait4x http https://httpbin.org/post --request-header "Content-Type: application/json" --request-body '{"key": "value", "name": "test"}'
 
+  # Put request with the body of a file, which is read again on every attempt:
+  wait4x http https://httpbin.org/put --request-method PUT --request-body-file ./body.json
+  wait4x http https://httpbin.org/put --request-method PUT --request-body @./body.json
+
+  # Basic authentication, bearer token, or OAuth2 client credentials, the secrets may be file or environment references
+  wait4x http https://api.example.com/health --basic-auth 'admin:env:ADMIN_PASSWORD'
+  wait4x http https://api.example.com/health --bearer-token-file /var/run/secrets/token
+  wait4x http https://api.example.com/health --oauth2-token-url https://auth.example.com/oauth/token \
+    --oauth2-client-id wait4x --oauth2-client-secret @/run/secrets/client_secret --oauth2-scope health:read
+
   # Disable auto redirect
   wait4x http https://www.wait4x.dev --expect-status-code 301 --no-redirect
 
   # Enable exponential backoff retry
   wait4x http https://ifconfig.co --expect-status-code 200 --backoff-policy exponential --backoff-exponential-max-interval 120s --timeout 120s
 
-  # Self-signed certificates
-  wait4x http https://www.wait4x.dev --cert-file /path/to/certfile --key-file /path/to/keyfile
+  # Client certificates
+  wait4x http https://www.wait4x.dev --tls-cert-file /path/to/certfile --tls-key-file /path/to/keyfile
 
   # CA file
-  wait4x http https://www.wait4x.dev --ca-file /path/to/cafile`,
+  wait4x http https://www.wait4x.dev --tls-ca-file /path/to/cafile
+
+  # Require TLS 1.3 and override the server name
+  wait4x http https://10.0.0.1 --tls-min-version 1.3 --tls-server-name www.wait4x.dev
+
+  # Check every IPv4 address behind the host
+  wait4x http https://www.wait4x.dev --all-addresses --ip-family 4
+
+  # Check the new load balancer with the production host name, its Host header and TLS validation
+  wait4x http https://www.wait4x.dev --resolve www.wait4x.dev:443:10.0.0.1 --tls-ca-file /path/to/cafile
+  wait4x http https://www.wait4x.dev --connect-to www.wait4x.dev:443:lb.internal:8443
+
+  # Check the QUIC listener with HTTP/3
+  wait4x http https://www.wait4x.dev --http3 --expect-status-code 200
+
+  # Check an HTTP server on a Unix domain socket
+  wait4x http unix:///var/run/app.sock:/health --expect-status-code 200
+  wait4x http http://localhost/v1.45/_ping --unix-socket /var/run/docker.sock
+
+  # Check all the instances discovered from SRV records
+  wait4x http srv+http://_api._tcp.api.service.consul/health --nameserver 127.0.0.1:8600`,
 		RunE: runHTTP,
 	}
 
-	httpCommand.Flags().Int("expect-status-code", 0, "Expect response code e.g. 200, 204, ... .")
-	httpCommand.Flags().String("expect-body-regex", "", "Expect response body pattern.")
-	httpCommand.Flags().String("expect-body-json", "", "Expect response body JSON pattern.")
-	httpCommand.Flags().String("expect-body-xpath", "", "Expect response body XPath pattern.")
-	httpCommand.Flags().String("expect-header", "", "Expect response header pattern.")
+	httpCommand.Flags().String("expect-status-code", "", "Expect response code, a list of codes, classes and ranges e.g. 200, 200,204, 2xx, 200-299 or !5xx.")
+	httpCommand.Flags().StringArray("expect-body-regex", nil, "Expect response body pattern, can be repeated and all must match.")
+	httpCommand.Flags().StringArray("expect-body-json", nil, "Expect response body JSON path, optionally compared with a value e.g. 'status == \"UP\"' or 'replicas >= 3', can be repeated and all must match.")
+	httpCommand.Flags().String("expect-body-json-schema", "", "Expect response body to validate against this JSON schema file.")
+	httpCommand.Flags().StringArray("expect-body-xpath", nil, "Expect response body XPath pattern, can be repeated and all must match.")
+	httpCommand.Flags().StringArray("expect-header", nil, "Expect response header pattern, can be repeated and all must match.")
+	httpCommand.Flags().Duration("expect-response-time", 0, "Expect the headers of the response within this time e.g. 200ms.")
+	httpCommand.Flags().Int("expect-response-time-consecutive", 1, "The number of consecutive checks that must respond within --expect-response-time.")
+	httpCommand.Flags().Int64("max-body-size", http.DefaultMaxBodySize, "Maximum size in bytes of the response body that is read for the body expectations.")
 	httpCommand.Flags().StringArray("request-header", nil, "User request headers.")
-	httpCommand.Flags().String("request-body", "", "User request body.")
+	httpCommand.Flags().String("request-body", "", "User request body, or @path to read it from a file.")
+	httpCommand.Flags().String("request-body-file", "", "Read the request body from a file on every attempt.")
+	httpCommand.Flags().String("request-method", "", "Request method (GET|HEAD|POST|PUT|PATCH|DELETE|OPTIONS), defaults to POST with a request body, otherwise GET.")
+	httpCommand.MarkFlagsMutuallyExclusive("request-body", "request-body-file")
+	httpCommand.Flags().String("basic-auth", "", "Authenticate with the basic authentication user:password.")
+	httpCommand.Flags().String("bearer-token", "", "Authenticate with the bearer token.")
+	httpCommand.Flags().String("bearer-token-file", "", "Authenticate with the bearer token of the file, that is read again on every attempt.")
+	httpCommand.Flags().String("oauth2-token-url", "", "Authenticate with an OAuth2 client credentials token of the token URL.")
+	httpCommand.Flags().String("oauth2-client-id", "", "The client ID of the OAuth2 client credentials.")
+	httpCommand.Flags().String("oauth2-client-secret", "", "The client secret of the OAuth2 client credentials.")
+	httpCommand.Flags().StringArray("oauth2-scope", nil, "The scope of the OAuth2 client credentials token, can be repeated.")
+	httpCommand.MarkFlagsMutuallyExclusive("bearer-token", "bearer-token-file", "oauth2-token-url")
+	httpCommand.MarkFlagsRequiredTogether("oauth2-token-url", "oauth2-client-id", "oauth2-client-secret")
 	httpCommand.Flags().
 		Duration("connection-timeout", http.DefaultConnectionTimeout, "Http connection timeout, The timeout includes connection time, any redirects, and reading the response body.")
-	httpCommand.Flags().
-		Bool("insecure-skip-tls-verify", http.DefaultInsecureSkipTLSVerify, "Skips tls certificate checks for the HTTPS request.")
 	httpCommand.Flags().
 		Bool("no-redirect", http.DefaultNoRedirect, "Do not follow HTTP 3xx redirects.")
-	httpCommand.Flags().
-		String("ca-file", "", "Use this CA bundle to authenticate certificates of servers with HTTPS enabled.")
-	httpCommand.Flags().
-		String("cert-file", "", "Utilize this SSL certificate file to identify the HTTPS client.")
-	httpCommand.Flags().
-		String("key-file", "", "Utilize this SSL key file to identify the HTTPS client.")
 	httpCommand.Flags().Bool("h2c", false, "Enable HTTP/2 cleartext (h2c) for http:// URLs.")
+	httpCommand.Flags().Bool("http3", false, "Use HTTP/3 over QUIC for https:// URLs.")
+	httpCommand.Flags().Bool("all-addresses", false, "Check every IP address the host resolves to, instead of the first reachable one.")
+	httpCommand.Flags().Int("ip-family", 0, "Restrict the connections to an IP family (4 or 6).")
+	httpCommand.Flags().String("unix-socket", "", "Connect to the Unix domain socket instead of the host of the URL.")
+	httpCommand.Flags().StringArray("resolve", nil, "Resolve the host and port to the address, host:port:address, without changing the URL, can be repeated.")
+	httpCommand.Flags().StringArray("connect-to", nil, "Connect to another host and port, host:port:target-host:target-port, without changing the URL, can be repeated.")
+	httpCommand.Flags().Bool("persistent", false, "Keep the connection open between the checks instead of reconnecting on every attempt.")
+	httpCommand.MarkFlagsMutuallyExclusive("http3", "h2c", "unix-socket")
+
+	tlsflags.Add(httpCommand.Flags())
+	dialflags.Add(httpCommand.Flags())
+	addTargetsFlags(httpCommand)
+
+	// The TLS flags used to be --ca-file, --cert-file and --key-file.
+	httpCommand.Flags().SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+		switch name {
+		case "ca-file", "cert-file", "key-file":
+			name = "tls-" + name
+		}
+
+		return pflag.NormalizedName(name)
+	})
+	addSRVFlags(httpCommand)
 
 	return httpCommand
 }
 
 func runHTTP(cmd *cobra.Command, args []string) error {
-	expectStatusCode, _ := cmd.Flags().GetInt("expect-status-code")
-	expectBodyRegex, _ := cmd.Flags().GetString("expect-body-regex")
-	expectBodyJSON, _ := cmd.Flags().GetString("expect-body-json")
-	expectBodyXPath, _ := cmd.Flags().GetString("expect-body-xpath")
-	expectHeader, _ := cmd.Flags().GetString("expect-header")
+	rawExpectStatusCode, _ := cmd.Flags().GetString("expect-status-code")
+	expectBodyRegex, _ := cmd.Flags().GetStringArray("expect-body-regex")
+	expectBodyJSON, _ := cmd.Flags().GetStringArray("expect-body-json")
+	expectBodyXPath, _ := cmd.Flags().GetStringArray("expect-body-xpath")
+	expectBodyJSONSchema, _ := cmd.Flags().GetString("expect-body-json-schema")
+	expectHeader, _ := cmd.Flags().GetStringArray("expect-header")
+	maxBodySize, _ := cmd.Flags().GetInt64("max-body-size")
+	expectResponseTime, _ := cmd.Flags().GetDuration("expect-response-time")
+	expectResponseTimeConsecutive, _ := cmd.Flags().GetInt("expect-response-time-consecutive")
 	requestRawHeaders, _ := cmd.Flags().GetStringArray("request-header")
 	requestBody, _ := cmd.Flags().GetString("request-body")
+	requestBodyFile, _ := cmd.Flags().GetString("request-body-file")
+	requestMethod, _ := cmd.Flags().GetString("request-method")
 	connectionTimeout, _ := cmd.Flags().GetDuration("connection-timeout")
-	insecureSkipTLSVerify, _ := cmd.Flags().GetBool("insecure-skip-tls-verify")
 	noRedirect, _ := cmd.Flags().GetBool("no-redirect")
-	caFile, _ := cmd.Flags().GetString("ca-file")
-	certFile, _ := cmd.Flags().GetString("cert-file")
-	keyFile, _ := cmd.Flags().GetString("key-file")
 	h2c, _ := cmd.Flags().GetBool("h2c")
+	http3, _ := cmd.Flags().GetBool("http3")
+	allAddresses, _ := cmd.Flags().GetBool("all-addresses")
+	rawIPFamily, _ := cmd.Flags().GetInt("ip-family")
+	unixSocket, _ := cmd.Flags().GetString("unix-socket")
+	rawResolve, _ := cmd.Flags().GetStringArray("resolve")
+	rawConnectTo, _ := cmd.Flags().GetStringArray("connect-to")
+	persistent, _ := cmd.Flags().GetBool("persistent")
 
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return err
 	}
 
-	// Validate cert and key files.
-	if (certFile != "" && keyFile == "") || (keyFile != "" && certFile == "") {
-		return fmt.Errorf(
-			"both certFile and keyFile should be assigned values, not just one of them",
-		)
+	ipFamily, err := checker.ParseIPFamily(rawIPFamily)
+	if err != nil {
+		return fmt.Errorf("failed to parse --ip-family flag: %w", err)
+	}
+
+	resolve := make([]http.ConnectTo, len(rawResolve))
+	for i, value := range rawResolve {
+		if resolve[i], err = http.ParseResolve(value); err != nil {
+			return fmt.Errorf("failed to parse --resolve flag: %w", err)
+		}
+	}
+
+	connectTo := make([]http.ConnectTo, len(rawConnectTo))
+	for i, value := range rawConnectTo {
+		if connectTo[i], err = http.ParseConnectTo(value); err != nil {
+			return fmt.Errorf("failed to parse --connect-to flag: %w", err)
+		}
+	}
+
+	srvOpts, err := getSRVOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
+	// The QUIC connections of HTTP/3 can't go through the proxy or the SSH jump host.
+	if http3 && dialer != nil {
+		return fmt.Errorf("failed to parse --http3 flag: %w", http.ErrHTTP3Dialer)
 	}
 
 	// Convert raw headers (e.g. 'a: b') into a http Header.
@@ -166,37 +294,117 @@ func runHTTP(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("can't parse the request header: %w", err)
 		}
 		requestHeaders = nethttp.Header(MIMEHeaders)
+
+		// The credentials may be file or environment references, the other headers are sent as they are.
+		for _, key := range []string{"Authorization", "Proxy-Authorization"} {
+			values := requestHeaders[key]
+			for i := range values {
+				if values[i], err = secretref.Resolve(values[i]); err != nil {
+					return fmt.Errorf("can't parse the request header: %w", err)
+				}
+			}
+		}
 	}
 
-	// ArgsLenAtDash returns -1 when -- was not specified
-	if i := cmd.ArgsLenAtDash(); i != -1 {
-		args = args[:i]
+	args, err = getTargets(cmd, args)
+	if err != nil {
+		return err
 	}
 
-	// Request body.
-	var requestBodyReader io.Reader
-	if len(requestBody) != 0 {
-		requestBodyReader = strings.NewReader(requestBody)
+	for _, arg := range args {
+		if _, err := url.Parse(arg); err != nil {
+			return checker.RedactError(err)
+		}
+	}
+
+	for _, expectation := range expectBodyJSON {
+		if err := http.ValidateExpectBodyJSON(expectation); err != nil {
+			return fmt.Errorf("failed to parse --expect-body-json flag: %w", err)
+		}
+	}
+
+	auth, err := authOption(cmd)
+	if err != nil {
+		return err
+	}
+
+	expectStatusCode := http.WithExpectStatusCode(0)
+	if rawExpectStatusCode != "" {
+		matcher, err := http.ParseStatusCodes(rawExpectStatusCode)
+		if err != nil {
+			return fmt.Errorf("failed to parse --expect-status-code flag: %w", err)
+		}
+		expectStatusCode = http.WithExpectStatusCodes(matcher)
+	}
+
+	if expectBodyJSONSchema != "" {
+		if err := http.ValidateExpectBodyJSONSchema(expectBodyJSONSchema); err != nil {
+			return fmt.Errorf("failed to parse --expect-body-json-schema flag: %w", err)
+		}
+	}
+
+	if expectResponseTime < 0 {
+		return errors.New("--expect-response-time must be positive")
+	}
+
+	if expectResponseTimeConsecutive < 1 {
+		return errors.New("--expect-response-time-consecutive must be positive")
+	}
+
+	if maxBodySize <= 0 {
+		return errors.New("--max-body-size must be positive")
+	}
+
+	requestMethod, err = parseRequestMethod(requestMethod)
+	if err != nil {
+		return fmt.Errorf("failed to parse --request-method flag: %w", err)
+	}
+
+	// Request body, like curl, "@path" is read from a file.
+	if path, ok := strings.CutPrefix(requestBody, secretref.FilePrefix); ok {
+		requestBody, requestBodyFile = "", path
+	}
+
+	if requestBodyFile != "" {
+		if _, err := os.Stat(requestBodyFile); err != nil {
+			return fmt.Errorf("failed to parse --request-body-file flag: %w", err)
+		}
 	}
 
 	checkers := make([]checker.Checker, len(args))
 	for i, arg := range args {
-		checkers[i] = http.New(arg,
-			http.WithExpectStatusCode(expectStatusCode),
-			http.WithExpectBodyRegex(expectBodyRegex),
-			http.WithExpectBodyJSON(expectBodyJSON),
-			http.WithExpectBodyXPath(expectBodyXPath),
-			http.WithExpectHeader(expectHeader),
-			http.WithRequestHeaders(requestHeaders),
-			http.WithRequestBody(requestBodyReader),
-			http.WithTimeout(connectionTimeout),
-			http.WithInsecureSkipTLSVerify(insecureSkipTLSVerify),
-			http.WithNoRedirect(noRedirect),
-			http.WithCAFile(caFile),
-			http.WithCertFile(certFile),
-			http.WithKeyFile(keyFile),
-			http.WithH2C(h2c),
-		)
+		checkers[i], err = newTargetChecker(arg, []string{"http", "https"}, srvOpts, func(target string) checker.Checker {
+			return http.New(target,
+				expectStatusCode,
+				http.WithExpectBodyRegex(expectBodyRegex...),
+				http.WithExpectBodyJSON(expectBodyJSON...),
+				http.WithExpectBodyXPath(expectBodyXPath...),
+				http.WithExpectBodyJSONSchema(expectBodyJSONSchema),
+				http.WithExpectHeader(expectHeader...),
+				http.WithExpectResponseTime(expectResponseTime),
+				http.WithExpectResponseTimeConsecutive(expectResponseTimeConsecutive),
+				http.WithMaxBodySize(maxBodySize),
+				http.WithRequestHeaders(requestHeaders),
+				requestBodyOption(requestBody, requestBodyFile),
+				http.WithRequestMethod(requestMethod),
+				auth,
+				http.WithTimeout(connectionTimeout),
+				http.WithNoRedirect(noRedirect),
+				http.WithTLSConfig(tlsConfig),
+				http.WithH2C(h2c),
+				http.WithHTTP3(http3),
+				http.WithAllAddresses(allAddresses),
+				http.WithIPFamily(ipFamily),
+				http.WithUnixSocket(unixSocket),
+				http.WithResolve(resolve...),
+				http.WithConnectTo(connectTo...),
+				http.WithDialer(dialer),
+				http.WithPersistent(persistent),
+			)
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	return waiter.WaitParallelContext(
@@ -211,5 +419,106 @@ func runHTTP(cmd *cobra.Command, args []string) error {
 			contextutil.GetBackoffExponentialMaxInterval(cmd.Context()),
 		),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
+
+// requestMethods are the methods of the --request-method flag
+var requestMethods = []string{
+	nethttp.MethodGet,
+	nethttp.MethodHead,
+	nethttp.MethodPost,
+	nethttp.MethodPut,
+	nethttp.MethodPatch,
+	nethttp.MethodDelete,
+	nethttp.MethodOptions,
+}
+
+// parseRequestMethod returns the upper case method, or an empty one to infer it from the request body
+func parseRequestMethod(method string) (string, error) {
+	if method == "" {
+		return "", nil
+	}
+
+	method = strings.ToUpper(method)
+	if !contains(requestMethods, method) {
+		return "", fmt.Errorf("%q isn't one of %v", method, requestMethods)
+	}
+
+	return method, nil
+}
+
+// requestBodyOption returns the request body option of a checker, a file is opened again on every attempt
+func requestBodyOption(requestBody, requestBodyFile string) http.Option {
+	if requestBodyFile != "" {
+		return http.WithRequestBodyFunc(func() (io.ReadCloser, error) {
+			return os.Open(requestBodyFile)
+		})
+	}
+
+	if requestBody == "" {
+		return http.WithRequestBody(nil)
+	}
+
+	return http.WithRequestBody(strings.NewReader(requestBody))
+}
+
+// authOption returns the authentication option of the flags
+func authOption(cmd *cobra.Command) (http.Option, error) {
+	var opts []http.Option
+
+	if basicAuth, _ := cmd.Flags().GetString("basic-auth"); basicAuth != "" {
+		username, password, found := strings.Cut(basicAuth, ":")
+		if !found {
+			return nil, errors.New("failed to parse --basic-auth flag: expected user:password")
+		}
+
+		password, err := secretref.ResolveSecret(password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --basic-auth flag: %w", err)
+		}
+		checker.AddSecrets(password)
+		opts = append(opts, http.WithBasicAuth(username, password))
+	}
+
+	if bearerToken, _ := cmd.Flags().GetString("bearer-token"); bearerToken != "" {
+		bearerToken, err := secretref.ResolveSecret(bearerToken)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --bearer-token flag: %w", err)
+		}
+		checker.AddSecrets(bearerToken)
+		opts = append(opts, http.WithBearerToken(bearerToken))
+	}
+
+	if bearerTokenFile, _ := cmd.Flags().GetString("bearer-token-file"); bearerTokenFile != "" {
+		if _, err := os.Stat(bearerTokenFile); err != nil {
+			return nil, fmt.Errorf("failed to parse --bearer-token-file flag: %w", err)
+		}
+		opts = append(opts, http.WithBearerTokenFile(bearerTokenFile))
+	}
+
+	if tokenURL, _ := cmd.Flags().GetString("oauth2-token-url"); tokenURL != "" {
+		clientID, _ := cmd.Flags().GetString("oauth2-client-id")
+		clientSecret, _ := cmd.Flags().GetString("oauth2-client-secret")
+		scopes, _ := cmd.Flags().GetStringArray("oauth2-scope")
+
+		clientSecret, err := secretref.ResolveSecret(clientSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --oauth2-client-secret flag: %w", err)
+		}
+
+		checker.AddSecrets(clientSecret)
+		opts = append(opts, http.WithOAuth2ClientCredentials(&clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     tokenURL,
+			Scopes:       scopes,
+		}))
+	}
+
+	return func(h *http.HTTP) {
+		for _, opt := range opts {
+			opt(h)
+		}
+	}, nil
+}
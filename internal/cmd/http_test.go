@@ -18,13 +18,23 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"encoding/pem"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
+	"github.com/quic-go/quic-go/http3"
 	"golang.org/x/net/http2"
 	"golang.org/x/net/http2/h2c"
+	"wait4x.dev/v3/checker"
+	httpchecker "wait4x.dev/v3/checker/http"
 	"wait4x.dev/v3/internal/test"
 
 	"github.com/stretchr/testify/assert"
@@ -109,6 +119,325 @@ func TestHTTPRequestHeaderSuccess(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+// TestHTTPRequestHeaderReference tests the HTTP command with credentials headers read from a file and the
+// environment, the other header values are sent as they are
+func TestHTTPRequestHeaderReference(t *testing.T) {
+	hts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer file-token" || r.Header.Get("Proxy-Authorization") != "Basic env-credentials" ||
+			r.Header.Get("X-Env") != "env:prod" || r.Header.Get("X-Src") != "file:abc" || r.Header.Get("X-Handle") != "@wait4x" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer hts.Close()
+
+	path := filepath.Join(t.TempDir(), "authorization")
+	assert.NoError(t, os.WriteFile(path, []byte("Bearer file-token\n"), 0o600))
+	t.Setenv("WAIT4X_TEST_PROXY_AUTH", "Basic env-credentials")
+	t.Setenv("prod", "resolved")
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err := test.ExecuteCommand(
+		rootCmd,
+		"http",
+		hts.URL,
+		"--request-header", "Authorization: @"+path,
+		"--request-header", "Proxy-Authorization: env:WAIT4X_TEST_PROXY_AUTH",
+		"--request-header", "X-Env: env:prod",
+		"--request-header", "X-Src: file:abc",
+		"--request-header", "X-Handle: @wait4x",
+		"--expect-status-code", "200",
+		"-t", "2s",
+	)
+	assert.NoError(t, err)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--request-header", "Authorization: env:WAIT4X_TEST_MISSING")
+	assert.ErrorContains(t, err, "can't parse the request header")
+}
+
+// TestHTTPExpectExpression tests the HTTP command with an expression of the observations
+func TestHTTPExpectExpression(t *testing.T) {
+	hts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version": "2.4.1", "ready": true}`))
+	}))
+	defer hts.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err := test.ExecuteCommand(
+		rootCmd,
+		"http",
+		hts.URL,
+		"--expect", `status_code == 200 && json.ready && json.version >= "2.3"`,
+		"-t", "2s",
+	)
+	assert.NoError(t, err)
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect", `json.version >= "3.0"`, "-t", "1s")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect", "status_code ==")
+	assert.ErrorContains(t, err, "invalid expect expression")
+}
+
+// TestHTTPRequestBodyRetries tests that the HTTP command sends the request body again on the retries
+func TestHTTPRequestBodyRetries(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	hts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r.Method+" "+string(body))
+		if len(requests) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer hts.Close()
+
+	path := filepath.Join(t.TempDir(), "body.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"key": "value"}`), 0o600))
+
+	for _, bodyFlags := range [][]string{
+		{"--request-body", `{"key": "value"}`},
+		{"--request-body-file", path},
+		{"--request-body", "@" + path},
+	} {
+		requests = nil
+
+		rootCmd := NewRootCommand()
+		rootCmd.AddCommand(NewHTTPCommand())
+
+		args := append([]string{"http", hts.URL, "--request-method", "put", "--expect-status-code", "200", "-i", "10ms", "-t", "2s"}, bodyFlags...)
+		_, err := test.ExecuteCommand(rootCmd, args...)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{`PUT {"key": "value"}`, `PUT {"key": "value"}`, `PUT {"key": "value"}`}, requests)
+	}
+}
+
+// TestHTTPRequestBodyAndMethodInvalid tests the HTTP command with an invalid request method and body file
+func TestHTTPRequestBodyAndMethodInvalid(t *testing.T) {
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "http", "http://127.0.0.1:8080", "--request-method", "CONNECT")
+	assert.ErrorContains(t, err, "failed to parse --request-method flag")
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", "http://127.0.0.1:8080", "--request-body", "@"+filepath.Join(t.TempDir(), "missing"))
+	assert.ErrorContains(t, err, "failed to parse --request-body-file flag")
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", "http://127.0.0.1:8080", "--request-body", "a", "--request-body-file", "b")
+	assert.ErrorContains(t, err, "none of the others can be")
+}
+
+// TestHTTPAuthentication tests the HTTP command with the basic, bearer and OAuth2 client credentials authentication
+func TestHTTPAuthentication(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id, secret, ok := r.BasicAuth(); !ok || id != "wait4x" || secret != "cmd-client-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "cmd-oauth2-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer tokenServer.Close()
+
+	hts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Basic YWRtaW46Y21kLXBhc3N3b3Jk", "Bearer cmd-bearer-token", "Bearer cmd-oauth2-token":
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer hts.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("cmd-bearer-token\n"), 0o600))
+	t.Setenv("WAIT4X_TEST_PASSWORD", "cmd-password")
+
+	for _, authFlags := range [][]string{
+		{"--basic-auth", "admin:env:WAIT4X_TEST_PASSWORD"},
+		{"--bearer-token", "cmd-bearer-token"},
+		{"--bearer-token", "@" + tokenFile},
+		{"--bearer-token-file", tokenFile},
+		{"--oauth2-token-url", tokenServer.URL, "--oauth2-client-id", "wait4x", "--oauth2-client-secret", "cmd-client-secret"},
+	} {
+		rootCmd := NewRootCommand()
+		rootCmd.AddCommand(NewHTTPCommand())
+
+		args := append([]string{"http", hts.URL, "--expect-status-code", "200", "-t", "2s"}, authFlags...)
+		_, err := test.ExecuteCommand(rootCmd, args...)
+		assert.NoError(t, err, authFlags)
+	}
+	assert.NotContains(t, checker.Redact("admin:cmd-password"), "cmd-password")
+	assert.NotContains(t, checker.Redact("secret cmd-client-secret"), "cmd-client-secret")
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "http", hts.URL, "--basic-auth", "admin")
+	assert.ErrorContains(t, err, "failed to parse --basic-auth flag")
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--bearer-token", "a", "--bearer-token-file", tokenFile)
+	assert.ErrorContains(t, err, "none of the others can be")
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--oauth2-token-url", tokenServer.URL)
+	assert.ErrorContains(t, err, "must all be set")
+}
+
+// TestHTTPExpectResponseTime tests the HTTP command with the expected response time of consecutive checks
+func TestHTTPExpectResponseTime(t *testing.T) {
+	var mu sync.Mutex
+	var requests int
+	hts := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests++
+	}))
+	defer hts.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-response-time", "1s", "--expect-response-time-consecutive", "3", "-i", "10ms", "-t", "2s")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, requests)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-response-time", "1ns", "-i", "10ms", "-t", "500ms")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-response-time", "1s", "--expect-response-time-consecutive", "0")
+	assert.ErrorContains(t, err, "--expect-response-time-consecutive must be positive")
+}
+
+// TestHTTPRepeatedBodyExpectations tests the HTTP command with several body expectations of one response
+func TestHTTPRepeatedBodyExpectations(t *testing.T) {
+	hts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "healthy", "version": "2.4.1"}`))
+	}))
+	defer hts.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err := test.ExecuteCommand(
+		rootCmd,
+		"http",
+		hts.URL,
+		"--expect-body-regex", `"healthy"`,
+		"--expect-body-json", "status",
+		"--expect-body-json", "version",
+		"-t", "2s",
+	)
+	assert.NoError(t, err)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-body-json", "status", "--expect-body-json", "missing", "-t", "1s")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--max-body-size", "0")
+	assert.ErrorContains(t, err, "--max-body-size must be positive")
+
+	large := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(make([]byte, 2*httpchecker.DefaultMaxBodySize))
+	}))
+	defer large.Close()
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	// The body isn't read without a body expectation, whatever its size.
+	_, err = test.ExecuteCommand(rootCmd, "http", large.URL, "--expect-status-code", "200", "-t", "2s")
+	assert.NoError(t, err)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-body-json", `status == "healthy"`, "--expect-body-json", `version >= "2.3"`, "-t", "2s")
+	assert.NoError(t, err)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-body-json", "status is text")
+	assert.ErrorContains(t, err, "failed to parse --expect-body-json flag")
+
+	schemaFile := filepath.Join(t.TempDir(), "status.schema.json")
+	assert.NoError(t, os.WriteFile(schemaFile, []byte(`{"type": "object", "required": ["status", "version"]}`), 0o600))
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-body-json-schema", schemaFile, "--expect-body-json", "status", "-t", "2s")
+	assert.NoError(t, err)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-body-json-schema", filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorContains(t, err, "failed to parse --expect-body-json-schema flag")
+}
+
+// TestHTTPExpectStatusCodes tests the HTTP command with lists, classes and ranges of status codes
+func TestHTTPExpectStatusCodes(t *testing.T) {
+	hts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer hts.Close()
+
+	for _, expression := range []string{"204", "200,204", "2xx", "200-299", "!5xx"} {
+		rootCmd := NewRootCommand()
+		rootCmd.AddCommand(NewHTTPCommand())
+
+		_, err := test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-status-code", expression, "-t", "2s")
+		assert.NoError(t, err, expression)
+	}
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err := test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-status-code", "2xx,!204", "-t", "1s")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", hts.URL, "--expect-status-code", "2xy")
+	assert.ErrorContains(t, err, "failed to parse --expect-status-code flag")
+}
+
 func TestHTTPRequestHeaderFail(t *testing.T) {
 	hts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -159,3 +488,168 @@ func TestHTTPH2CFlagSuccess(t *testing.T) {
 	)
 	assert.Nil(t, err)
 }
+
+func TestHTTPCAFileFlagAlias(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, ca, 0o600))
+
+	for _, flag := range []string{"--tls-ca-file", "--ca-file"} {
+		rootCmd := NewRootCommand()
+		rootCmd.AddCommand(NewHTTPCommand())
+
+		_, err := test.ExecuteCommand(rootCmd, "http", ts.URL, flag, caFile)
+
+		assert.Nil(t, err)
+	}
+}
+
+// TestHTTPResolveAndConnectTo tests the HTTP command with the resolve and connect-to flags and a CA file
+func TestHTTPResolveAndConnectTo(t *testing.T) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "example.com" {
+			w.WriteHeader(http.StatusMisdirectedRequest)
+		}
+	}))
+	defer ts.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, ca, 0o600))
+
+	_, port, err := net.SplitHostPort(ts.Listener.Addr().String())
+	assert.NoError(t, err)
+
+	for _, flags := range [][]string{
+		{"--connect-to", "example.com:443:127.0.0.1:" + port},
+		{"--connect-to", "example.com:443::" + port, "--resolve", "example.com:" + port + ":127.0.0.1"},
+	} {
+		rootCmd := NewRootCommand()
+		rootCmd.AddCommand(NewHTTPCommand())
+
+		args := append([]string{"http", "https://example.com", "--ca-file", caFile, "--expect-status-code", "200", "-t", "2s"}, flags...)
+		_, err := test.ExecuteCommand(rootCmd, args...)
+		assert.NoError(t, err, flags)
+	}
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", "https://example.com", "--resolve", "example.com:443")
+	assert.ErrorContains(t, err, "failed to parse --resolve flag")
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", "https://example.com", "--connect-to", "example.com:443:lb")
+	assert.ErrorContains(t, err, "failed to parse --connect-to flag")
+}
+
+// TestHTTPUnixSocket tests the HTTP command over a Unix domain socket
+func TestHTTPUnixSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "wait4x")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	socket := filepath.Join(dir, "app.sock")
+	listener, err := net.Listen("unix", socket)
+	assert.NoError(t, err)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": "UP"}`))
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	for _, args := range [][]string{
+		{"unix://" + socket + ":/health"},
+		{"http://localhost/health", "--unix-socket", socket},
+	} {
+		rootCmd := NewRootCommand()
+		rootCmd.AddCommand(NewHTTPCommand())
+
+		args = append([]string{"http"}, args...)
+		_, err := test.ExecuteCommand(rootCmd, append(args, "--expect-status-code", "200", "--expect-body-json", `status == "UP"`, "-t", "2s")...)
+		assert.NoError(t, err, args)
+	}
+}
+
+// TestHTTPHTTP3 tests the HTTP command over HTTP/3 with a CA file
+func TestHTTPHTTP3(t *testing.T) {
+	// The certificate of the TLS test server is reused for the QUIC listener.
+	ts := httptest.NewTLSServer(http.NotFoundHandler())
+	defer ts.Close()
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw})
+	assert.NoError(t, os.WriteFile(caFile, ca, 0o600))
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer conn.Close()
+
+	server := &http3.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ProtoMajor != 3 {
+				w.WriteHeader(http.StatusHTTPVersionNotSupported)
+			}
+		}),
+		TLSConfig: http3.ConfigureTLSConfig(&tls.Config{Certificates: ts.TLS.Certificates}),
+	}
+	go func() { _ = server.Serve(conn) }()
+	defer server.Close()
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", "https://"+conn.LocalAddr().String(), "--http3", "--ca-file", caFile, "--expect-status-code", "200", "-t", "2s")
+	assert.NoError(t, err)
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", "https://"+conn.LocalAddr().String(), "--http3", "--h2c")
+	assert.ErrorContains(t, err, "none of the others can be")
+}
+
+// TestHTTPSSHJump tests the HTTP command through an SSH jump host, and that HTTP/3 can't go through it
+func TestHTTPSSHJump(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	sshServer, err := test.NewSSHServer()
+	assert.NoError(t, err)
+	defer sshServer.Close()
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "id_ed25519")
+	assert.NoError(t, os.WriteFile(keyFile, sshServer.ClientKeyPEM, 0o600))
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	assert.NoError(t, os.WriteFile(knownHostsFile, sshServer.KnownHosts, 0o600))
+
+	rootCmd := NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", ts.URL, "--expect-status-code", "200", "-t", "2s",
+		"--ssh-jump", "wait4x@"+sshServer.Addr, "--ssh-key-file", keyFile, "--ssh-known-hosts", knownHostsFile)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sshServer.Connections())
+
+	rootCmd = NewRootCommand()
+	rootCmd.AddCommand(NewHTTPCommand())
+
+	_, err = test.ExecuteCommand(rootCmd, "http", "https://127.0.0.1:443", "--http3", "--proxy", "socks5://127.0.0.1:1080")
+	assert.ErrorIs(t, err, httpchecker.ErrHTTP3Dialer)
+	assert.ErrorContains(t, err, "failed to parse --http3 flag")
+}
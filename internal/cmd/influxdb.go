@@ -27,6 +27,8 @@ import (
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/influxdb"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -35,8 +37,8 @@ func NewInfluxDBCommand() *cobra.Command {
 	influxdbCommand := &cobra.Command{
 		Use:   "influxdb SERVER_URL... [flags] [-- command [args...]]",
 		Short: "Check InfluxDB connection",
-		Args: func(_ *cobra.Command, args []string) error {
-			if len(args) < 1 {
+		Args: func(cmd *cobra.Command, args []string) error {
+			if !hasTargets(cmd, args) {
 				return errors.New("SERVER_URL is required argument for the influxdb command")
 			}
 
@@ -49,23 +51,38 @@ func NewInfluxDBCommand() *cobra.Command {
 		RunE: runInfluxDB,
 	}
 
+	tlsflags.Add(influxdbCommand.Flags())
+	dialflags.Add(influxdbCommand.Flags())
+	addTargetsFlags(influxdbCommand)
+
 	return influxdbCommand
 }
 
 func runInfluxDB(cmd *cobra.Command, args []string) error {
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("unable to get logger from context: %w", err)
 	}
 
-	// ArgsLenAtDash returns -1 when -- was not specified
-	if i := cmd.ArgsLenAtDash(); i != -1 {
-		args = args[:i]
+	args, err = getTargets(cmd, args)
+	if err != nil {
+		return err
 	}
 
 	checkers := make([]checker.Checker, len(args))
 	for i, arg := range args {
-		checkers[i] = influxdb.New(arg)
+		checkers[i] = influxdb.New(arg, influxdb.WithTLSConfig(tlsConfig), influxdb.WithDialer(dialer))
 	}
 
 	return waiter.WaitParallelContext(
@@ -78,5 +95,6 @@ func runInfluxDB(cmd *cobra.Command, args []string) error {
 		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
 		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
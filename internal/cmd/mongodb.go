@@ -27,6 +27,8 @@ import (
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/mongodb"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -35,8 +37,8 @@ func NewMongoDBCommand() *cobra.Command {
 	mongodbCommand := &cobra.Command{
 		Use:   "mongodb DSN... [flags] [-- command [args...]]",
 		Short: "Check MongoDB connection",
-		Args: func(_ *cobra.Command, args []string) error {
-			if len(args) < 1 {
+		Args: func(cmd *cobra.Command, args []string) error {
+			if !hasTargets(cmd, args) {
 				return errors.New("DSN is required argument for the mongodb command")
 			}
 
@@ -52,23 +54,50 @@ func NewMongoDBCommand() *cobra.Command {
 		RunE: runMongoDB,
 	}
 
+	mongodbCommand.Flags().Bool("persistent", false, "Keep the connection open between the checks instead of reconnecting on every attempt.")
+
+	tlsflags.Add(mongodbCommand.Flags())
+	dialflags.Add(mongodbCommand.Flags())
+	addTargetsFlags(mongodbCommand)
+
 	return mongodbCommand
 }
 
 func runMongoDB(cmd *cobra.Command, args []string) error {
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("unable to get logger from context: %w", err)
 	}
 
-	// ArgsLenAtDash returns -1 when -- was not specified
-	if i := cmd.ArgsLenAtDash(); i != -1 {
-		args = args[:i]
+	args, err = getTargets(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	persistent, err := cmd.Flags().GetBool("persistent")
+	if err != nil {
+		return fmt.Errorf("failed to parse --persistent flag: %w", err)
 	}
 
 	checkers := make([]checker.Checker, len(args))
 	for i, arg := range args {
-		checkers[i] = mongodb.New(arg)
+		checkers[i] = mongodb.New(
+			arg,
+			mongodb.WithTLSConfig(tlsConfig),
+			mongodb.WithDialer(dialer),
+			mongodb.WithPersistent(persistent),
+		)
 	}
 
 	return waiter.WaitParallelContext(
@@ -81,5 +110,6 @@ func runMongoDB(cmd *cobra.Command, args []string) error {
 		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
 		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
@@ -27,6 +27,8 @@ import (
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/mysql"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -35,8 +37,8 @@ func NewMysqlCommand() *cobra.Command {
 	mysqlCommand := &cobra.Command{
 		Use:   "mysql DSN... [flags] [-- command [args...]]",
 		Short: "Check MySQL connection",
-		Args: func(_ *cobra.Command, args []string) error {
-			if len(args) < 1 {
+		Args: func(cmd *cobra.Command, args []string) error {
+			if !hasTargets(cmd, args) {
 				return errors.New("DSN is required argument for the mysql command")
 			}
 
@@ -48,24 +50,46 @@ func NewMysqlCommand() *cobra.Command {
 
   # Checking MySQL UNIX Socket existence
   wait4x mysql username:password@unix(/tmp/mysql.sock)/myDatabase
+
+  # Checking MySQL with the DSN of a file
+  wait4x mysql file:/run/secrets/mysql_dsn
+
+  # Checking MySQL through a SOCKS5 proxy
+  wait4x mysql user:password@tcp(db.internal:3306)/dbname --proxy socks5://bastion:1080
 `,
 		RunE: runMysql,
 	}
 
 	mysqlCommand.Flags().String("expect-table", "", "Expect a table to exist in the database")
+	mysqlCommand.Flags().Bool("persistent", false, "Keep the connection open between the checks instead of reconnecting on every attempt.")
+
+	tlsflags.Add(mysqlCommand.Flags())
+	dialflags.Add(mysqlCommand.Flags())
+	addTargetsFlags(mysqlCommand)
 
 	return mysqlCommand
 }
 
 func runMysql(cmd *cobra.Command, args []string) error {
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("unable to get logger from context: %w", err)
 	}
 
-	// ArgsLenAtDash returns -1 when -- was not specified
-	if i := cmd.ArgsLenAtDash(); i != -1 {
-		args = args[:i]
+	args, err = getTargets(cmd, args)
+	if err != nil {
+		return err
 	}
 
 	expectTable, err := cmd.Flags().GetString("expect-table")
@@ -73,9 +97,20 @@ func runMysql(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --expect-table flag: %w", err)
 	}
 
+	persistent, err := cmd.Flags().GetBool("persistent")
+	if err != nil {
+		return fmt.Errorf("failed to parse --persistent flag: %w", err)
+	}
+
 	checkers := make([]checker.Checker, len(args))
 	for i, arg := range args {
-		checkers[i] = mysql.New(arg, mysql.WithExpectTable(expectTable))
+		checkers[i] = mysql.New(
+			arg,
+			mysql.WithExpectTable(expectTable),
+			mysql.WithTLSConfig(tlsConfig),
+			mysql.WithDialer(dialer),
+			mysql.WithPersistent(persistent),
+		)
 	}
 
 	return waiter.WaitParallelContext(
@@ -88,5 +123,6 @@ func runMysql(cmd *cobra.Command, args []string) error {
 		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
 		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
@@ -26,6 +26,8 @@ import (
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/rabbitmq"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -34,8 +36,8 @@ func NewRabbitMQCommand() *cobra.Command {
 	rabbitmqCommand := &cobra.Command{
 		Use:   "rabbitmq DSN... [flags] [-- command [args...]]",
 		Short: "Check RabbitMQ connection",
-		Args: func(_ *cobra.Command, args []string) error {
-			if len(args) < 1 {
+		Args: func(cmd *cobra.Command, args []string) error {
+			if !hasTargets(cmd, args) {
 				return errors.New("DSN is required argument for the rabbitmq sub-command")
 			}
 
@@ -52,7 +54,10 @@ func NewRabbitMQCommand() *cobra.Command {
 	}
 
 	rabbitmqCommand.Flags().Duration("connection-timeout", rabbitmq.DefaultConnectionTimeout, "Timeout is the maximum amount of time a dial will wait for a connection to complete.")
-	rabbitmqCommand.Flags().Bool("insecure-skip-tls-verify", rabbitmq.DefaultInsecureSkipTLSVerify, "InsecureSkipTLSVerify controls whether a client verifies the server's certificate chain and hostname.")
+
+	tlsflags.Add(rabbitmqCommand.Flags())
+	dialflags.Add(rabbitmqCommand.Flags())
+	addTargetsFlags(rabbitmqCommand)
 
 	return rabbitmqCommand
 }
@@ -63,19 +68,25 @@ func runRabbitMQ(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unable to parse --connection-timeout flag: %w", err)
 	}
 
-	insecureSkipTLSVerify, err := cmd.Flags().GetBool("insecure-skip-tls-verify")
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
 	if err != nil {
-		return fmt.Errorf("unable to parse --insecure-skip-tls-verify flag: %w", err)
+		return err
 	}
 
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("unable to get logger from context: %w", err)
 	}
 
-	// ArgsLenAtDash returns -1 when -- was not specified
-	if i := cmd.ArgsLenAtDash(); i != -1 {
-		args = args[:i]
+	args, err = getTargets(cmd, args)
+	if err != nil {
+		return err
 	}
 
 	checkers := make([]checker.Checker, len(args))
@@ -83,7 +94,8 @@ func runRabbitMQ(cmd *cobra.Command, args []string) error {
 		checkers[i] = rabbitmq.New(
 			arg,
 			rabbitmq.WithTimeout(conTimeout),
-			rabbitmq.WithInsecureSkipTLSVerify(insecureSkipTLSVerify),
+			rabbitmq.WithTLSConfig(tlsConfig),
+			rabbitmq.WithDialer(dialer),
 		)
 	}
 
@@ -97,5 +109,6 @@ func runRabbitMQ(cmd *cobra.Command, args []string) error {
 		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
 		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
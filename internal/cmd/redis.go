@@ -26,6 +26,8 @@ import (
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/redis"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -34,8 +36,8 @@ func NewRedisCommand() *cobra.Command {
 	redisCommand := &cobra.Command{
 		Use:   "redis ADDRESS... [flags] [-- command [args...]]",
 		Short: "Check Redis connection or key existence",
-		Args: func(_ *cobra.Command, args []string) error {
-			if len(args) < 1 {
+		Args: func(cmd *cobra.Command, args []string) error {
+			if !hasTargets(cmd, args) {
 				return errors.New("ADDRESS is required argument for the redis command")
 			}
 
@@ -56,12 +58,21 @@ func NewRedisCommand() *cobra.Command {
 
   # Checking a key existence and matching the value
   wait4x redis redis://127.0.0.1:6379 --expect-key "FOO=^b[A-Z]r$"
+
+  # Checking any of the Redis nodes discovered from SRV records
+  wait4x redis srv+redis://_redis._tcp.cache.service.consul/1 --srv-mode any
 `,
 		RunE: runRedis,
 	}
 
 	redisCommand.Flags().Duration("connection-timeout", redis.DefaultConnectionTimeout, "Dial timeout for establishing new connections.")
 	redisCommand.Flags().String("expect-key", "", "Checking key existence.")
+	redisCommand.Flags().Bool("persistent", false, "Keep the connection open between the checks instead of reconnecting on every attempt.")
+
+	tlsflags.Add(redisCommand.Flags())
+	dialflags.Add(redisCommand.Flags())
+	addTargetsFlags(redisCommand)
+	addSRVFlags(redisCommand)
 
 	return redisCommand
 }
@@ -78,23 +89,52 @@ func runRedis(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --expect-key flag: %w", err)
 	}
 
+	persistent, err := cmd.Flags().GetBool("persistent")
+	if err != nil {
+		return fmt.Errorf("failed to parse --persistent flag: %w", err)
+	}
+
+	srvOpts, err := getSRVOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("failed to get logger from context: %w", err)
 	}
 
-	// ArgsLenAtDash returns -1 when -- was not specified
-	if i := cmd.ArgsLenAtDash(); i != -1 {
-		args = args[:i]
+	args, err = getTargets(cmd, args)
+	if err != nil {
+		return err
 	}
 
 	checkers := make([]checker.Checker, len(args))
 	for i, arg := range args {
-		checkers[i] = redis.New(
-			arg,
-			redis.WithExpectKey(expectKey),
-			redis.WithTimeout(conTimeout),
-		)
+		checkers[i], err = newTargetChecker(arg, []string{"redis", "rediss"}, srvOpts, func(target string) checker.Checker {
+			return redis.New(
+				target,
+				redis.WithExpectKey(expectKey),
+				redis.WithTimeout(conTimeout),
+				redis.WithTLSConfig(tlsConfig),
+				redis.WithDialer(dialer),
+				redis.WithPersistent(persistent),
+			)
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	return waiter.WaitParallelContext(
@@ -107,5 +147,6 @@ func runRedis(cmd *cobra.Command, args []string) error {
 		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
 		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
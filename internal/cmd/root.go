@@ -30,6 +30,7 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 
+	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/internal/cmd/dns"
 	"wait4x.dev/v3/internal/cmd/temporal"
 	"wait4x.dev/v3/internal/contextutil"
@@ -94,12 +95,27 @@ func NewRootCommand() *cobra.Command {
 				return fmt.Errorf("unable to parse --backoff-exponential-max-interval flag: %w", err)
 			}
 
+			redactPatterns, err := cmd.Flags().GetStringArray("redact-pattern")
+			if err != nil {
+				return fmt.Errorf("unable to parse --redact-pattern flag: %w", err)
+			}
+
+			if err := checker.SetRedactPatterns(redactPatterns); err != nil {
+				return fmt.Errorf("unable to parse --redact-pattern flag: %w", err)
+			}
+
+			expect, err := cmd.Flags().GetString("expect")
+			if err != nil {
+				return fmt.Errorf("unable to parse --expect flag: %w", err)
+			}
+
 			cmd.SetContext(contextutil.WithTimeout(cmd.Context(), timeout))
 			cmd.SetContext(contextutil.WithInterval(cmd.Context(), interval))
 			cmd.SetContext(contextutil.WithInvertCheck(cmd.Context(), invertCheck))
 			cmd.SetContext(contextutil.WithBackoffPolicy(cmd.Context(), backoffPolicy))
 			cmd.SetContext(contextutil.WithBackoffCoefficient(cmd.Context(), backoffCoefficient))
 			cmd.SetContext(contextutil.WithBackoffExponentialMaxInterval(cmd.Context(), backoffExpMaxInterval))
+			cmd.SetContext(contextutil.WithExpect(cmd.Context(), expect))
 
 			// Validate backoff policy value
 			backoffPolicyValues := []string{waiter.BackoffPolicyExponential, waiter.BackoffPolicyLinear}
@@ -168,6 +184,8 @@ func NewRootCommand() *cobra.Command {
 	rootCmd.PersistentFlags().MarkDeprecated("log-level", "You don't need to the flag anymore. By default, Wait4X returns error logs. This flag will be removed in v4.0.0")
 	rootCmd.PersistentFlags().Bool("no-color", false, "If specified, output won't contain any color.")
 	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Quiet or silent mode. Do not show logs or error messages.")
+	rootCmd.PersistentFlags().String("expect", "", "Expect the observations of the check to match this expression, e.g. 'status_code == 200 && json.version >= \"2.3\"' or 'len(brokers) >= 3'.")
+	rootCmd.PersistentFlags().StringArray("redact-pattern", nil, "Redact the secrets that match this regular expression in the logs and errors, only its first group when it has one, e.g. 'token=(\\w+)'.")
 
 	return rootCmd
 }
@@ -0,0 +1,97 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"wait4x.dev/v3/checker"
+	"wait4x.dev/v3/checker/srv"
+)
+
+// srvSchemePrefix is the scheme prefix of the targets that are discovered from SRV records.
+const srvSchemePrefix = "srv+"
+
+// addSRVFlags adds the flags of the commands that accept srv+ targets.
+func addSRVFlags(cmd *cobra.Command) {
+	cmd.Flags().String("srv-mode", string(srv.DefaultMode), "How many of the addresses discovered from a srv+ target must be ready (any, all or quorum).")
+	cmd.Flags().String("nameserver", "", "Nameserver to resolve the SRV records of srv+ targets, e.g. 8.8.8.8:53.")
+}
+
+// getSRVOptions returns the SRV checker options from the --srv-mode and --nameserver flags.
+func getSRVOptions(cmd *cobra.Command) ([]srv.Option, error) {
+	rawMode, err := cmd.Flags().GetString("srv-mode")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --srv-mode flag: %w", err)
+	}
+
+	mode, err := srv.ParseMode(rawMode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --srv-mode flag: %w", err)
+	}
+
+	nameserver, err := cmd.Flags().GetString("nameserver")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --nameserver flag: %w", err)
+	}
+
+	return []srv.Option{srv.WithMode(mode), srv.WithNameServer(nameserver)}, nil
+}
+
+// newTargetChecker returns the checker that newChecker creates for the target. A srv+ target,
+// e.g. srv+http://_http._tcp.api.service.consul/health, gets an SRV checker instead, which checks
+// each discovered address with the checker that newChecker creates for the target rewritten to the
+// address, e.g. http://10.0.0.1:8080/health. A srv+tcp target is rewritten to the bare address.
+func newTargetChecker(
+	target string,
+	schemes []string,
+	srvOpts []srv.Option,
+	newChecker func(target string) checker.Checker,
+) (checker.Checker, error) {
+	if !strings.HasPrefix(target, srvSchemePrefix) {
+		return newChecker(target), nil
+	}
+
+	u, err := url.Parse(strings.TrimPrefix(target, srvSchemePrefix))
+	if err != nil {
+		return nil, checker.RedactError(fmt.Errorf("can't parse the SRV target: %w", err))
+	}
+
+	if !slices.Contains(schemes, u.Scheme) {
+		return nil, checker.RedactError(fmt.Errorf("unsupported scheme in the SRV target %q, expected one of srv+%s", target, strings.Join(schemes, ", srv+")))
+	}
+
+	if u.Host == "" {
+		return nil, checker.RedactError(fmt.Errorf("the SRV target %q has no service", target))
+	}
+
+	factory := func(address string) checker.Checker {
+		if u.Scheme == "tcp" {
+			return newChecker(address)
+		}
+
+		discovered := *u
+		discovered.Host = address
+
+		return newChecker(discovered.String())
+	}
+
+	return srv.New(u.Host, factory, srvOpts...), nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"wait4x.dev/v3/internal/secretref"
+	"wait4x.dev/v3/internal/targets"
+)
+
+// addTargetsFlags adds the flags of the multi-target commands.
+func addTargetsFlags(cmd *cobra.Command) {
+	cmd.Flags().String("targets-file", "", "Read newline-separated targets from a file, use - to read them from stdin.")
+	cmd.Flags().Bool("no-expand", false, "Do not expand brace lists and numeric ranges in targets, e.g. host-{a,b} or 10.0.0.[1-5].")
+}
+
+// hasTargets reports whether the command received a target as an argument or through --targets-file.
+func hasTargets(cmd *cobra.Command, args []string) bool {
+	return len(args) > 0 || cmd.Flags().Changed("targets-file")
+}
+
+// getTargets returns the targets passed before "--" followed by the ones read from --targets-file,
+// with their brace lists and numeric ranges expanded and their file and environment references resolved.
+func getTargets(cmd *cobra.Command, args []string) ([]string, error) {
+	targetsFile, err := cmd.Flags().GetString("targets-file")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --targets-file flag: %w", err)
+	}
+
+	noExpand, err := cmd.Flags().GetBool("no-expand")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --no-expand flag: %w", err)
+	}
+
+	// ArgsLenAtDash returns -1 when -- was not specified
+	if i := cmd.ArgsLenAtDash(); i != -1 {
+		args = args[:i]
+	}
+
+	// Clone the arguments to keep the command after "--" untouched.
+	result := slices.Clone(args)
+
+	if targetsFile != "" {
+		fileTargets, err := targets.ReadFile(targetsFile, cmd.InOrStdin())
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, fileTargets...)
+	}
+
+	if !noExpand {
+		result, err = targets.ExpandAll(result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result, err = secretref.ResolveAll(result)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("no target has been provided")
+	}
+
+	return result, nil
+}
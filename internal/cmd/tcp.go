@@ -15,6 +15,7 @@
 package cmd
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 
@@ -23,6 +24,8 @@ import (
 	"wait4x.dev/v3/checker"
 	"wait4x.dev/v3/checker/tcp"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -31,8 +34,8 @@ func NewTCPCommand() *cobra.Command {
 	tcpCommand := &cobra.Command{
 		Use:   "tcp ADDRESS... [flags] [-- command [args...]]",
 		Short: "Check TCP connection",
-		Args: func(_ *cobra.Command, args []string) error {
-			if len(args) < 1 {
+		Args: func(cmd *cobra.Command, args []string) error {
+			if !hasTargets(cmd, args) {
 				return errors.New("ADDRESS is required argument for the tcp command")
 			}
 
@@ -41,11 +44,37 @@ func NewTCPCommand() *cobra.Command {
 		Example: `
   # If you want checking just tcp connection
   wait4x tcp 127.0.0.1:9090
+
+  # If you want reading the addresses from stdin (one per line)
+  cat addresses.txt | wait4x tcp --targets-file -
+
+  # If you want checking every address behind a round-robin DNS name
+  wait4x tcp db.internal:5432 --all-addresses --ip-family 4
+
+  # If you want checking the TLS handshake and pinning the server public key
+  wait4x tcp ldap.internal:636 --tls --tls-pin-sha256 sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=
+
+  # If you want connecting through a SOCKS5 proxy
+  wait4x tcp db.internal:5432 --proxy socks5://bastion:1080
+
+  # If you want connecting through an SSH jump host
+  wait4x tcp db.internal:5432 --ssh-jump deploy@bastion.example.com:22
+
+  # If you want checking the addresses discovered from SRV records, a quorum of them must be ready
+  wait4x tcp srv+tcp://_postgres._tcp.db.service.consul --srv-mode quorum --nameserver 127.0.0.1:8600
 `,
 		RunE: runTCP,
 	}
 
 	tcpCommand.Flags().Duration("connection-timeout", tcp.DefaultConnectionTimeout, "Timeout is the maximum amount of time a dial will wait for a connection to complete.")
+	tcpCommand.Flags().Bool("all-addresses", false, "Check every IP address the host resolves to, instead of the first reachable one.")
+	tcpCommand.Flags().Int("ip-family", 0, "Restrict the connections to an IP family (4 or 6).")
+
+	tcpCommand.Flags().Bool("tls", false, "Check the TLS handshake over the connection, implied by the other TLS flags.")
+	tlsflags.Add(tcpCommand.Flags())
+	dialflags.Add(tcpCommand.Flags())
+	addTargetsFlags(tcpCommand)
+	addSRVFlags(tcpCommand)
 
 	return tcpCommand
 }
@@ -56,19 +85,71 @@ func runTCP(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --connection-timeout flag: %w", err)
 	}
 
+	allAddresses, err := cmd.Flags().GetBool("all-addresses")
+	if err != nil {
+		return fmt.Errorf("failed to parse --all-addresses flag: %w", err)
+	}
+
+	rawIPFamily, err := cmd.Flags().GetInt("ip-family")
+	if err != nil {
+		return fmt.Errorf("failed to parse --ip-family flag: %w", err)
+	}
+
+	ipFamily, err := checker.ParseIPFamily(rawIPFamily)
+	if err != nil {
+		return fmt.Errorf("failed to parse --ip-family flag: %w", err)
+	}
+
+	srvOpts, err := getSRVOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
+	useTLS, err := cmd.Flags().GetBool("tls")
+	if err != nil {
+		return fmt.Errorf("failed to parse --tls flag: %w", err)
+	}
+
+	if useTLS && tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
 		return fmt.Errorf("failed to get logger from context: %w", err)
 	}
 
-	// ArgsLenAtDash returns -1 when -- was not specified
-	if i := cmd.ArgsLenAtDash(); i != -1 {
-		args = args[:i]
+	args, err = getTargets(cmd, args)
+	if err != nil {
+		return err
 	}
 
 	checkers := make([]checker.Checker, len(args))
 	for i, arg := range args {
-		checkers[i] = tcp.New(arg, tcp.WithTimeout(conTimeout))
+		checkers[i], err = newTargetChecker(arg, []string{"tcp"}, srvOpts, func(address string) checker.Checker {
+			return tcp.New(
+				address,
+				tcp.WithTimeout(conTimeout),
+				tcp.WithAllAddresses(allAddresses),
+				tcp.WithIPFamily(ipFamily),
+				tcp.WithTLSConfig(tlsConfig),
+				tcp.WithDialer(dialer),
+			)
+		})
+		if err != nil {
+			return err
+		}
 	}
 
 	return waiter.WaitParallelContext(
@@ -81,5 +162,6 @@ func runTCP(cmd *cobra.Command, args []string) error {
 		waiter.WithBackoffCoefficient(contextutil.GetBackoffCoefficient(cmd.Context())),
 		waiter.WithBackoffExponentialMaxInterval(contextutil.GetBackoffExponentialMaxInterval(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
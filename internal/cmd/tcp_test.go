@@ -17,8 +17,16 @@ package cmd
 
 import (
 	"context"
+	"encoding/pem"
+	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -195,6 +203,204 @@ func (s *TCPCommandSuite) TestTCPMultipleAddressesMixed() {
 	s.Equal(context.DeadlineExceeded, err)
 }
 
+// TestTCPCommandWithTargetsFile tests the TCP command with targets read from a file
+func (s *TCPCommandSuite) TestTCPCommandWithTargetsFile() {
+	path := filepath.Join(s.T().TempDir(), "targets")
+	content := "# local server\n" + s.listener.Addr().String() + "\n\n"
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", "--targets-file", path)
+	s.NoError(err)
+}
+
+// TestTCPCommandWithTargetReferences tests the TCP command with targets read from files and the environment
+func (s *TCPCommandSuite) TestTCPCommandWithTargetReferences() {
+	path := filepath.Join(s.T().TempDir(), "address")
+	s.Require().NoError(os.WriteFile(path, []byte(s.listener.Addr().String()+"\n"), 0o600))
+	s.T().Setenv("WAIT4X_TEST_ADDRESS", s.listener.Addr().String())
+
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", "file:"+path, "@"+path, "env:WAIT4X_TEST_ADDRESS", "-t", "2s")
+	s.NoError(err)
+
+	_, err = test.ExecuteCommand(s.rootCmd, "tcp", "env:WAIT4X_TEST_MISSING")
+	s.ErrorContains(err, "env:WAIT4X_TEST_MISSING")
+}
+
+// TestTCPCommandWithTargetsStdin tests the TCP command with targets read from stdin
+func (s *TCPCommandSuite) TestTCPCommandWithTargetsStdin() {
+	s.rootCmd.SetIn(strings.NewReader(s.listener.Addr().String() + "\n"))
+
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", "--targets-file", "-", "-t", "2s")
+	s.NoError(err)
+}
+
+// TestTCPCommandWithTargetsFileAndArgs tests the TCP command with targets from both arguments and a file
+func (s *TCPCommandSuite) TestTCPCommandWithTargetsFileAndArgs() {
+	path := filepath.Join(s.T().TempDir(), "targets")
+	address := net.JoinHostPort("127.0.0.1", strconv.Itoa(s.unusedPort))
+	s.Require().NoError(os.WriteFile(path, []byte(address+"\n"), 0o600))
+
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", s.listener.Addr().String(), "--targets-file", path, "-t", "2s")
+	s.Error(err)
+	s.Equal(context.DeadlineExceeded, err)
+}
+
+// TestTCPCommandWithEmptyTargetsFile tests the TCP command with a targets file without any target
+func (s *TCPCommandSuite) TestTCPCommandWithEmptyTargetsFile() {
+	path := filepath.Join(s.T().TempDir(), "targets")
+	s.Require().NoError(os.WriteFile(path, []byte("# nothing\n"), 0o600))
+
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", "--targets-file", path)
+	s.Error(err)
+	s.Equal("no target has been provided", err.Error())
+}
+
+// TestTCPCommandWithRangeExpansion tests the TCP command with a numeric range in the address
+func (s *TCPCommandSuite) TestTCPCommandWithRangeExpansion() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", fmt.Sprintf("127.0.0.[1-1]:%d", s.port), "-t", "2s")
+	s.NoError(err)
+
+	_, err = test.ExecuteCommand(s.rootCmd, "tcp", fmt.Sprintf("127.0.0.1:[%d-%d]", s.port, s.unusedPort), "-t", "2s")
+	s.Error(err)
+	s.Equal(context.DeadlineExceeded, err)
+}
+
+// TestTCPCommandWithBraceExpansion tests the TCP command with a brace list in the address
+func (s *TCPCommandSuite) TestTCPCommandWithBraceExpansion() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", fmt.Sprintf("127.0.0.1:{%d,%d}", s.port, s.unusedPort), "-t", "2s")
+	s.Error(err)
+	s.Equal(context.DeadlineExceeded, err)
+}
+
+// TestTCPCommandWithNoExpand tests the TCP command with the expansion disabled
+func (s *TCPCommandSuite) TestTCPCommandWithNoExpand() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", fmt.Sprintf("127.0.0.[1-1]:%d", s.port), "--no-expand", "-t", "1s")
+	s.Error(err)
+}
+
+// TestTCPCommandWithAllAddresses tests the TCP command with checking every address of the host
+func (s *TCPCommandSuite) TestTCPCommandWithAllAddresses() {
+	address := net.JoinHostPort("localhost", strconv.Itoa(s.port))
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", address, "--all-addresses", "--ip-family", "4", "-t", "2s")
+	s.NoError(err)
+}
+
+// TestTCPCommandWithInvalidIPFamily tests the TCP command with an invalid IP family
+func (s *TCPCommandSuite) TestTCPCommandWithInvalidIPFamily() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", s.listener.Addr().String(), "--ip-family", "5")
+	s.Error(err)
+	s.Contains(err.Error(), "failed to parse --ip-family flag")
+}
+
+// TestTCPCommandWithInvalidSRVMode tests the TCP command with an invalid SRV mode
+func (s *TCPCommandSuite) TestTCPCommandWithInvalidSRVMode() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", "srv+tcp://_db._tcp.wait4x.test", "--srv-mode", "most")
+	s.Error(err)
+	s.Contains(err.Error(), "failed to parse --srv-mode flag")
+}
+
+// TestTCPCommandWithUnsupportedSRVScheme tests the TCP command with an SRV target of another scheme
+func (s *TCPCommandSuite) TestTCPCommandWithUnsupportedSRVScheme() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", "srv+http://_db._tcp.wait4x.test")
+	s.Error(err)
+	s.Contains(err.Error(), "unsupported scheme in the SRV target")
+}
+
+// TestTCPCommandWithTLS tests the TCP command with a TLS handshake
+func (s *TCPCommandSuite) TestTCPCommandWithTLS() {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer server.Close()
+
+	caFile := filepath.Join(s.T().TempDir(), "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	s.Require().NoError(os.WriteFile(caFile, ca, 0o600))
+
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", server.Listener.Addr().String(), "--tls-ca-file", caFile)
+	s.NoError(err)
+
+	_, err = test.ExecuteCommand(s.rootCmd, "tcp", s.listener.Addr().String(), "--tls", "-t", "1s")
+	s.Equal(context.DeadlineExceeded, err)
+}
+
+// TestTCPCommandWithInvalidTLSVersion tests the TCP command with an invalid TLS version
+func (s *TCPCommandSuite) TestTCPCommandWithInvalidTLSVersion() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", s.listener.Addr().String(), "--tls-min-version", "2.0")
+	s.Error(err)
+	s.Contains(err.Error(), "failed to parse --tls-min-version flag")
+}
+
+// TestTCPCommandWithProxy tests the TCP command through an HTTP CONNECT proxy
+func (s *TCPCommandSuite) TestTCPCommandWithProxy() {
+	var tunnels atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		upstream, err := net.Dial("tcp", r.Host)
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer upstream.Close()
+		tunnels.Add(1)
+
+		w.WriteHeader(http.StatusOK)
+		conn, _, err := http.NewResponseController(w).Hijack()
+		if err != nil {
+			return
+		}
+		conn.Close()
+	}))
+	defer proxy.Close()
+
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", s.listener.Addr().String(), "--proxy", proxy.URL)
+	s.NoError(err)
+	s.Equal(int32(1), tunnels.Load())
+
+	s.T().Setenv("ALL_PROXY", proxy.URL)
+	_, err = test.ExecuteCommand(s.rootCmd, "tcp", s.listener.Addr().String())
+	s.NoError(err)
+	s.Equal(int32(2), tunnels.Load())
+}
+
+// TestTCPCommandWithSSHJump tests the TCP command through an SSH jump host
+func (s *TCPCommandSuite) TestTCPCommandWithSSHJump() {
+	sshServer, err := test.NewSSHServer()
+	s.Require().NoError(err)
+	defer sshServer.Close()
+
+	dir := s.T().TempDir()
+	keyFile := filepath.Join(dir, "id_ed25519")
+	s.Require().NoError(os.WriteFile(keyFile, sshServer.ClientKeyPEM, 0o600))
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	s.Require().NoError(os.WriteFile(knownHostsFile, sshServer.KnownHosts, 0o600))
+
+	_, err = test.ExecuteCommand(
+		s.rootCmd, "tcp", s.listener.Addr().String(), s.listener.Addr().String(),
+		"--ssh-jump", "wait4x@"+sshServer.Addr,
+		"--ssh-key-file", keyFile,
+		"--ssh-known-hosts", knownHostsFile,
+	)
+	s.NoError(err)
+	s.Equal(1, sshServer.Connections())
+}
+
+// TestTCPCommandWithUnsupportedProxy tests the TCP command with an unsupported proxy scheme
+func (s *TCPCommandSuite) TestTCPCommandWithUnsupportedProxy() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", s.listener.Addr().String(), "--proxy", "ftp://127.0.0.1:21")
+	s.Error(err)
+	s.Contains(err.Error(), "failed to parse --proxy flag")
+}
+
+// TestTCPCommandWithInvalidRedactPattern tests the TCP command with an invalid redact pattern
+func (s *TCPCommandSuite) TestTCPCommandWithInvalidRedactPattern() {
+	_, err := test.ExecuteCommand(s.rootCmd, "tcp", s.listener.Addr().String(), "--redact-pattern", "(")
+	s.Error(err)
+	s.Contains(err.Error(), "unable to parse --redact-pattern flag")
+}
+
 // TestTCPCommandWithDash tests the TCP command with dash separator for command execution
 func (s *TCPCommandSuite) TestTCPCommandWithDash() {
 	_, err := test.ExecuteCommand(s.rootCmd, "tcp", "1.1.1.1:53", "--", "echo", "success")
@@ -26,6 +26,9 @@ import (
 
 	"wait4x.dev/v3/checker/temporal"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/secretref"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -47,6 +50,9 @@ func NewServerCommand() *cobra.Command {
 
   # Checking insecure Temporal server (no TLS)
   wait4x temporal server 127.0.0.1:7233 --insecure-transport
+
+  # Checking Temporal server with mutual TLS
+  wait4x temporal server temporal.internal:7233 --tls-ca-file ca.pem --tls-cert-file client.pem --tls-key-file client-key.pem
 `,
 		RunE: runServer,
 	}
@@ -66,10 +72,21 @@ func runServer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse insecure-transport flag: %w", err)
 	}
 
-	insecureSkipTLSVerify, err := cmd.Flags().GetBool("insecure-skip-tls-verify")
+	persistent, err := cmd.Flags().GetBool("persistent")
+	if err != nil {
+		return fmt.Errorf("failed to parse persistent flag: %w", err)
+	}
+
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
 	if err != nil {
-		return fmt.Errorf("failed to parse insecure-skip-tls-verify flag: %w", err)
+		return err
 	}
+	defer closeDialer()
 
 	logger, err := logr.FromContext(cmd.Context())
 	if err != nil {
@@ -81,12 +98,19 @@ func runServer(cmd *cobra.Command, args []string) error {
 		args = args[:i]
 	}
 
+	target, err := secretref.Resolve(args[0])
+	if err != nil {
+		return err
+	}
+
 	tc := temporal.New(
 		temporal.CheckModeServer,
-		args[0],
+		target,
 		temporal.WithTimeout(conTimeout),
 		temporal.WithInsecureTransport(insecureTransport),
-		temporal.WithInsecureSkipTLSVerify(insecureSkipTLSVerify),
+		temporal.WithTLSConfig(tlsConfig),
+		temporal.WithDialer(dialer),
+		temporal.WithPersistent(persistent),
 	)
 
 	return waiter.WaitContext(
@@ -96,5 +120,6 @@ func runServer(cmd *cobra.Command, args []string) error {
 		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
 		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
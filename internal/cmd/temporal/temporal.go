@@ -21,6 +21,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"wait4x.dev/v3/checker/temporal"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/tlsflags"
 )
 
 // NewTemporalCommand creates a new temporal sub-command
@@ -32,7 +34,9 @@ func NewTemporalCommand() *cobra.Command {
 
 	temporalCommand.PersistentFlags().Duration("connection-timeout", temporal.DefaultConnectionTimeout, "Timeout is the maximum amount of time a dial will wait for a GRPC connection to complete.")
 	temporalCommand.PersistentFlags().Bool("insecure-transport", temporal.DefaultInsecureTransport, "Skips GRPC transport security.")
-	temporalCommand.PersistentFlags().Bool("insecure-skip-tls-verify", temporal.DefaultInsecureSkipTLSVerify, "Skips tls certificate checks for the GRPC request.")
+	temporalCommand.PersistentFlags().Bool("persistent", false, "Keep the connection open between the checks instead of reconnecting on every attempt.")
+	tlsflags.Add(temporalCommand.PersistentFlags())
+	dialflags.Add(temporalCommand.PersistentFlags())
 
 	temporalCommand.AddCommand(NewServerCommand())
 	temporalCommand.AddCommand(NewWorkerCommand())
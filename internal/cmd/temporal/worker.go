@@ -26,6 +26,9 @@ import (
 
 	"wait4x.dev/v3/checker/temporal"
 	"wait4x.dev/v3/internal/contextutil"
+	"wait4x.dev/v3/internal/dialflags"
+	"wait4x.dev/v3/internal/secretref"
+	"wait4x.dev/v3/internal/tlsflags"
 	"wait4x.dev/v3/waiter"
 )
 
@@ -72,11 +75,22 @@ func runWorker(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse --insecure-transport flag: %w", err)
 	}
 
-	insecureSkipTLSVerify, err := cmd.Flags().GetBool("insecure-skip-tls-verify")
+	persistent, err := cmd.Flags().GetBool("persistent")
 	if err != nil {
-		return fmt.Errorf("failed to parse --insecure-skip-tls-verify flag: %w", err)
+		return fmt.Errorf("failed to parse --persistent flag: %w", err)
 	}
 
+	tlsConfig, err := tlsflags.Config(cmd.Flags())
+	if err != nil {
+		return err
+	}
+
+	dialer, closeDialer, err := dialflags.Dialer(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	defer closeDialer()
+
 	namespace, err := cmd.Flags().GetString("namespace")
 	if err != nil {
 		return fmt.Errorf("failed to parse --namespace flag: %w", err)
@@ -102,12 +116,19 @@ func runWorker(cmd *cobra.Command, args []string) error {
 		args = args[:i]
 	}
 
+	target, err := secretref.Resolve(args[0])
+	if err != nil {
+		return err
+	}
+
 	tc := temporal.New(
 		temporal.CheckModeWorker,
-		args[0],
+		target,
 		temporal.WithTimeout(conTimeout),
 		temporal.WithInsecureTransport(insecureTransport),
-		temporal.WithInsecureSkipTLSVerify(insecureSkipTLSVerify),
+		temporal.WithTLSConfig(tlsConfig),
+		temporal.WithDialer(dialer),
+		temporal.WithPersistent(persistent),
 		temporal.WithNamespace(namespace),
 		temporal.WithTaskQueue(taskQueue),
 		temporal.WithExpectWorkerIdentityRegex(expectWorkerIdentityRegex),
@@ -120,5 +141,6 @@ func runWorker(cmd *cobra.Command, args []string) error {
 		waiter.WithInterval(contextutil.GetInterval(cmd.Context())),
 		waiter.WithInvertCheck(contextutil.GetInvertCheck(cmd.Context())),
 		waiter.WithLogger(logger),
+		waiter.WithExpect(contextutil.GetExpect(cmd.Context())),
 	)
 }
@@ -28,6 +28,7 @@ type (
 	backoffPolicyCtxKey                 struct{}
 	backoffCoefficientCtxKey            struct{}
 	backoffExponentialMaxIntervalCtxKey struct{}
+	expectCtxKey                        struct{}
 )
 
 // WithTimeout returns a new context with the given timeout value.
@@ -107,3 +108,16 @@ func GetBackoffExponentialMaxInterval(ctx context.Context) time.Duration {
 	}
 	return 0
 }
+
+// WithExpect returns a new context with the given expect expression.
+func WithExpect(ctx context.Context, expect string) context.Context {
+	return context.WithValue(ctx, expectCtxKey{}, expect)
+}
+
+// GetExpect retrieves the expect expression from the given context.
+func GetExpect(ctx context.Context) string {
+	if v := ctx.Value(expectCtxKey{}); v != nil {
+		return v.(string)
+	}
+	return ""
+}
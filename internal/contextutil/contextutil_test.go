@@ -157,6 +157,17 @@ func (s *ContextUtilSuite) TestBackoffExponentialMaxIntervalFunctions() {
 	s.Equal(time.Duration(0), GetBackoffExponentialMaxInterval(ctxWithZeroInterval))
 }
 
+// TestExpectFunctions tests both WithExpect and GetExpect functions
+func (s *ContextUtilSuite) TestExpectFunctions() {
+	ctx := context.Background()
+
+	ctxWithExpect := WithExpect(ctx, "status_code == 200")
+	s.Equal("status_code == 200", GetExpect(ctxWithExpect))
+
+	// Test that original context is not modified
+	s.Equal("", GetExpect(ctx))
+}
+
 // TestMultipleValues tests setting multiple values on the same context
 func (s *ContextUtilSuite) TestMultipleValues() {
 	ctx := context.Background()
@@ -0,0 +1,173 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialflags
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/suite"
+
+	"wait4x.dev/v3/checker/dialer"
+	"wait4x.dev/v3/internal/test"
+)
+
+// DialFlagsSuite is a test suite for dialflags package
+type DialFlagsSuite struct {
+	suite.Suite
+}
+
+// SetupTest clears the proxy and SSH environment variables
+func (s *DialFlagsSuite) SetupTest() {
+	for _, name := range []string{"ALL_PROXY", "all_proxy", "NO_PROXY", "no_proxy", "SSH_AUTH_SOCK"} {
+		s.T().Setenv(name, "")
+	}
+	s.T().Setenv("HOME", s.T().TempDir())
+}
+
+// parse adds the dialer flags to a new flag set and parses the arguments
+func (s *DialFlagsSuite) parse(args ...string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	Add(flags)
+	s.Require().NoError(flags.Parse(args))
+
+	return flags
+}
+
+// TestDialerWithoutProxy tests that the connections are direct without a proxy
+func (s *DialFlagsSuite) TestDialerWithoutProxy() {
+	d, _, err := Dialer(s.parse())
+	s.NoError(err)
+	s.Nil(d)
+}
+
+// TestDialer tests the dialer of the --proxy flag and the environment
+func (s *DialFlagsSuite) TestDialer() {
+	d, _, err := Dialer(s.parse("--proxy", "socks5://127.0.0.1:1080"))
+	s.NoError(err)
+	s.NotNil(d)
+
+	s.T().Setenv("ALL_PROXY", "http://127.0.0.1:3128")
+	d, _, err = Dialer(s.parse())
+	s.NoError(err)
+	s.NotNil(d)
+}
+
+// TestDialerInvalidProxy tests the errors of an invalid proxy
+func (s *DialFlagsSuite) TestDialerInvalidProxy() {
+	_, _, err := Dialer(s.parse("--proxy", "ftp://127.0.0.1:21"))
+	s.ErrorIs(err, dialer.ErrUnsupportedProxy)
+	s.ErrorContains(err, "failed to parse --proxy flag")
+
+	s.T().Setenv("ALL_PROXY", "://invalid")
+	_, _, err = Dialer(s.parse())
+	s.ErrorContains(err, "ALL_PROXY")
+}
+
+// TestDialerSSHJump tests the dialer through an SSH jump host
+func (s *DialFlagsSuite) TestDialerSSHJump() {
+	sshServer, err := test.NewSSHServer()
+	s.Require().NoError(err)
+	defer sshServer.Close()
+
+	dir := s.T().TempDir()
+	keyFile := filepath.Join(dir, "id_ed25519")
+	s.Require().NoError(os.WriteFile(keyFile, sshServer.ClientKeyPEM, 0o600))
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	s.Require().NoError(os.WriteFile(knownHostsFile, sshServer.KnownHosts, 0o600))
+
+	target, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	defer target.Close()
+	go func() {
+		for {
+			conn, err := target.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	d, closeDialer, err := Dialer(s.parse(
+		"--ssh-jump", "wait4x@"+sshServer.Addr,
+		"--ssh-key-file", keyFile,
+		"--ssh-known-hosts", knownHostsFile,
+	))
+	s.Require().NoError(err)
+	defer closeDialer()
+
+	for range 2 {
+		conn, err := d(context.Background(), "tcp", target.Addr().String())
+		s.Require().NoError(err)
+		conn.Close()
+	}
+	s.Equal(1, sshServer.Connections())
+
+	// The host key of the server isn't in the default known_hosts file.
+	_, _, err = Dialer(s.parse("--ssh-jump", "wait4x@"+sshServer.Addr, "--ssh-key-file", keyFile))
+	s.ErrorContains(err, "failed to parse --ssh-known-hosts flag")
+
+	s.Require().NoError(os.MkdirAll(filepath.Join(os.Getenv("HOME"), ".ssh"), 0o700))
+	s.Require().NoError(os.WriteFile(filepath.Join(os.Getenv("HOME"), ".ssh", "known_hosts"), nil, 0o600))
+	d, closeDialer, err = Dialer(s.parse("--ssh-jump", "wait4x@"+sshServer.Addr, "--ssh-key-file", keyFile))
+	s.Require().NoError(err)
+	defer closeDialer()
+
+	_, err = d(context.Background(), "tcp", target.Addr().String())
+	s.ErrorContains(err, "key is unknown")
+}
+
+// TestDialerSSHJumpWithoutAuthMethod tests an SSH jump host without any key or agent
+func (s *DialFlagsSuite) TestDialerSSHJumpWithoutAuthMethod() {
+	knownHostsFile := filepath.Join(s.T().TempDir(), "known_hosts")
+	s.Require().NoError(os.WriteFile(knownHostsFile, nil, 0o600))
+
+	_, _, err := Dialer(s.parse("--ssh-jump", "bastion", "--ssh-known-hosts", knownHostsFile))
+	s.ErrorIs(err, ErrNoSSHAuthMethod)
+}
+
+// TestParseSSHJump tests parsing the address of the SSH jump host
+func (s *DialFlagsSuite) TestParseSSHJump() {
+	tests := []struct {
+		sshJump  string
+		username string
+		address  string
+	}{
+		{"bob@bastion", "bob", "bastion:22"},
+		{"bob@bastion:2222", "bob", "bastion:2222"},
+		{"bob@[::1]", "bob", "[::1]:22"},
+		{"bob@[::1]:2222", "bob", "[::1]:2222"},
+	}
+
+	for _, tt := range tests {
+		username, address, err := parseSSHJump(tt.sshJump)
+		s.Require().NoError(err)
+		s.Equal(tt.username, username)
+		s.Equal(tt.address, address)
+	}
+
+	_, _, err := parseSSHJump("bob@")
+	s.Error(err)
+}
+
+// TestDialFlagsSuite runs the test suite
+func TestDialFlagsSuite(t *testing.T) {
+	suite.Run(t, new(DialFlagsSuite))
+}
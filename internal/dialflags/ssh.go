@@ -0,0 +1,170 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dialflags
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"wait4x.dev/v3/checker/dialer"
+)
+
+// defaultSSHPort is the port of the SSH jump host when the address has none
+const defaultSSHPort = "22"
+
+// defaultKeyFiles are the private keys of ~/.ssh that are used when --ssh-key-file isn't set
+var defaultKeyFiles = []string{"id_ed25519", "id_ecdsa", "id_rsa"}
+
+// ErrNoSSHAuthMethod defines the error of an SSH jump host without any way to authenticate
+var ErrNoSSHAuthMethod = errors.New("no ssh authentication method, set --ssh-key-file or run an ssh agent")
+
+// sshJumpDialer returns the dialer through the SSH jump host, which is reached with forward
+func sshJumpDialer(flags *pflag.FlagSet, sshJump string, forward dialer.DialFunc) (dialer.DialFunc, func(), error) {
+	noop := func() {}
+
+	username, address, err := parseSSHJump(sshJump)
+	if err != nil {
+		return nil, noop, fmt.Errorf("failed to parse --ssh-jump flag: %w", err)
+	}
+
+	keyFiles, err := flags.GetStringArray("ssh-key-file")
+	if err != nil {
+		return nil, noop, fmt.Errorf("failed to parse --ssh-key-file flag: %w", err)
+	}
+
+	knownHostsFile, err := flags.GetString("ssh-known-hosts")
+	if err != nil {
+		return nil, noop, fmt.Errorf("failed to parse --ssh-known-hosts flag: %w", err)
+	}
+
+	home, _ := os.UserHomeDir()
+
+	if knownHostsFile == "" {
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, noop, fmt.Errorf("failed to parse --ssh-known-hosts flag: %w", err)
+	}
+
+	var authMethods []ssh.AuthMethod
+
+	signers, err := loadSigners(keyFiles, home)
+	if err != nil {
+		return nil, noop, fmt.Errorf("failed to parse --ssh-key-file flag: %w", err)
+	}
+	if len(signers) > 0 {
+		authMethods = append(authMethods, ssh.PublicKeys(signers...))
+	}
+
+	var agentConn net.Conn
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		agentConn, err = net.Dial("unix", socket)
+		if err != nil {
+			return nil, noop, fmt.Errorf("failed to connect to the ssh agent: %w", err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeysCallback(agent.NewClient(agentConn).Signers))
+	}
+
+	if len(authMethods) == 0 {
+		return nil, noop, ErrNoSSHAuthMethod
+	}
+
+	jump := dialer.NewSSHJump(address, &ssh.ClientConfig{
+		User:            username,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+	}, forward)
+
+	closeJump := func() {
+		jump.Close()
+		if agentConn != nil {
+			agentConn.Close()
+		}
+	}
+
+	return jump.DialContext, closeJump, nil
+}
+
+// parseSSHJump parses the [user@]host[:port] of the SSH jump host, the user defaults to the current one
+func parseSSHJump(sshJump string) (username, address string, err error) {
+	username, hostPort, found := strings.Cut(sshJump, "@")
+	if !found {
+		hostPort = username
+		username = ""
+	}
+
+	if hostPort == "" {
+		return "", "", fmt.Errorf("missing host in %q", sshJump)
+	}
+
+	if _, _, err := net.SplitHostPort(hostPort); err != nil {
+		hostPort = net.JoinHostPort(strings.Trim(hostPort, "[]"), defaultSSHPort)
+	}
+
+	if username == "" {
+		current, err := user.Current()
+		if err != nil {
+			return "", "", fmt.Errorf("missing user in %q: %w", sshJump, err)
+		}
+		username = current.Username
+	}
+
+	return username, hostPort, nil
+}
+
+// loadSigners loads the private key files, or the default ones of ~/.ssh that exist
+func loadSigners(keyFiles []string, home string) ([]ssh.Signer, error) {
+	explicit := len(keyFiles) > 0
+	if !explicit && home != "" {
+		for _, name := range defaultKeyFiles {
+			keyFiles = append(keyFiles, filepath.Join(home, ".ssh", name))
+		}
+	}
+
+	var signers []ssh.Signer
+	for _, keyFile := range keyFiles {
+		key, err := os.ReadFile(keyFile)
+		if err != nil {
+			if !explicit && errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			var passphraseErr *ssh.PassphraseMissingError
+			if !explicit && errors.As(err, &passphraseErr) {
+				continue // left to the ssh agent
+			}
+			return nil, fmt.Errorf("can't parse %s: %w", keyFile, err)
+		}
+		signers = append(signers, signer)
+	}
+
+	return signers, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secretref resolves the references to the files and the environment variables that hold
+// the secrets of the Wait4X commands, e.g. the DSNs with passwords and the credentials.
+package secretref
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"wait4x.dev/v3/checker"
+)
+
+const (
+	// FilePrefix is the prefix of a reference to a file, e.g. @/run/secrets/token. The secret flags read any
+	// @path, the other values only an existing file of an absolute or a ./ path, e.g. @/run/secrets/db_dsn.
+	FilePrefix = "@"
+	// FileSchemePrefix is the prefix of a reference to a file, e.g. file:/run/secrets/db_dsn
+	FileSchemePrefix = "file:"
+	// EnvPrefix is the prefix of a reference to an environment variable, e.g. env:DATABASE_URL
+	EnvPrefix = "env:"
+)
+
+var (
+	// envNameRegexp matches the names of the environment variables
+	envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	// portRegexp matches a port, so "file:80" stays the address of a host named file
+	portRegexp = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// ErrEnvNotSet defines the error of a reference to an unset environment variable
+var ErrEnvNotSet = errors.New("environment variable is not set")
+
+// Resolve returns the content of the file of a file:path reference, or of a @/path or @./path reference
+// to an existing file, without its trailing newline, or the value of the environment variable of an
+// env:NAME reference. Any other value is returned as it is, e.g. the MySQL DSN @tcp(localhost:3306)/db.
+// The resolved values are redacted from the logs and the errors.
+func Resolve(value string) (string, error) {
+	return redacted(value, resolve)
+}
+
+// ResolveSecret resolves the value of a secret flag like Resolve, and also reads the file of a @path reference
+func ResolveSecret(value string) (string, error) {
+	return redacted(value, func(value string) (string, bool, error) {
+		if path, ok := strings.CutPrefix(value, FilePrefix); ok && path != "" {
+			return readFile(value, path)
+		}
+
+		return resolve(value)
+	})
+}
+
+// ResolveAll resolves each of the values
+func ResolveAll(values []string) ([]string, error) {
+	resolved := make([]string, len(values))
+	for i, value := range values {
+		var err error
+		if resolved[i], err = Resolve(value); err != nil {
+			return nil, err
+		}
+	}
+
+	return resolved, nil
+}
+
+// redacted resolves the value with resolveFn and redacts the resolved value
+func redacted(value string, resolveFn func(value string) (string, bool, error)) (string, error) {
+	resolved, ok, err := resolveFn(value)
+	if err != nil || !ok {
+		return value, err
+	}
+
+	checker.AddSecrets(resolved)
+
+	return resolved, nil
+}
+
+// resolve resolves the value, ok is false when it isn't a reference
+func resolve(value string) (resolved string, ok bool, err error) {
+	switch {
+	case strings.HasPrefix(value, FileSchemePrefix) && !portRegexp.MatchString(strings.TrimPrefix(value, FileSchemePrefix)):
+		return readFile(value, strings.TrimPrefix(value, FileSchemePrefix))
+
+	case strings.HasPrefix(value, FilePrefix+"/") || strings.HasPrefix(value, FilePrefix+"./"):
+		// The MySQL DSNs may start with @/ too, e.g. @/db of the default address, so the path must be a file.
+		path := strings.TrimPrefix(value, FilePrefix)
+		if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+			return readFile(value, path)
+		}
+
+	case strings.HasPrefix(value, EnvPrefix) && envNameRegexp.MatchString(strings.TrimPrefix(value, EnvPrefix)):
+		name := strings.TrimPrefix(value, EnvPrefix)
+		resolved, ok := os.LookupEnv(name)
+		if !ok {
+			return "", false, fmt.Errorf("can't resolve %q: %w", value, ErrEnvNotSet)
+		}
+
+		return resolved, true, nil
+	}
+
+	return value, false, nil
+}
+
+// readFile reads the file of the reference, the errors only mention the reference
+func readFile(ref, path string) (string, bool, error) {
+	if path == "" {
+		return "", false, fmt.Errorf("can't resolve %q: missing file path", ref)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("can't resolve %q: %w", ref, err)
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), true, nil
+}
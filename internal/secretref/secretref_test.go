@@ -0,0 +1,127 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secretref
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"wait4x.dev/v3/checker"
+)
+
+// SecretRefSuite is a test suite for secretref package
+type SecretRefSuite struct {
+	suite.Suite
+}
+
+// TestResolveFile tests resolving the references to a file
+func (s *SecretRefSuite) TestResolveFile() {
+	path := filepath.Join(s.T().TempDir(), "db_dsn")
+	s.Require().NoError(os.WriteFile(path, []byte("postgres://bob:file-secret@db:5432/app\n"), 0o600))
+
+	value, err := Resolve("file:" + path)
+	s.NoError(err)
+	s.Equal("postgres://bob:file-secret@db:5432/app", value)
+
+	value, err = Resolve("@" + path)
+	s.NoError(err)
+	s.Equal("postgres://bob:file-secret@db:5432/app", value)
+
+	s.T().Chdir(filepath.Dir(path))
+	value, err = Resolve("@./db_dsn")
+	s.NoError(err)
+	s.Equal("postgres://bob:file-secret@db:5432/app", value)
+
+	_, err = Resolve("file:" + filepath.Join(s.T().TempDir(), "missing"))
+	s.ErrorIs(err, os.ErrNotExist)
+
+	_, err = Resolve("file:")
+	s.ErrorContains(err, "missing file path")
+}
+
+// TestResolveEnv tests resolving the references to an environment variable
+func (s *SecretRefSuite) TestResolveEnv() {
+	s.T().Setenv("WAIT4X_TEST_DSN", "bob:env-secret@tcp(db:3306)/app")
+
+	value, err := Resolve("env:WAIT4X_TEST_DSN")
+	s.NoError(err)
+	s.Equal("bob:env-secret@tcp(db:3306)/app", value)
+
+	_, err = Resolve("env:WAIT4X_TEST_MISSING")
+	s.ErrorIs(err, ErrEnvNotSet)
+}
+
+// TestResolveValue tests that the values that aren't references are returned as they are
+func (s *SecretRefSuite) TestResolveValue() {
+	for _, value := range []string{
+		"127.0.0.1:5432", "file:80", "env:8080", "@", "https://example.com/@me",
+		"@tcp(host:3306)/db", "@unix(/var/run/mysqld.sock)/db", "@/db", "@./db", "@" + s.T().TempDir(),
+	} {
+		resolved, err := Resolve(value)
+		s.NoError(err)
+		s.Equal(value, resolved)
+	}
+}
+
+// TestResolveSecret tests that the secret flags also read the file of a @path reference
+func (s *SecretRefSuite) TestResolveSecret() {
+	path := filepath.Join(s.T().TempDir(), "token")
+	s.Require().NoError(os.WriteFile(path, []byte("file-token\n"), 0o600))
+	s.T().Setenv("WAIT4X_TEST_TOKEN", "env-token")
+
+	for ref, expected := range map[string]string{
+		"@" + path:              "file-token",
+		"file:" + path:          "file-token",
+		"env:WAIT4X_TEST_TOKEN": "env-token",
+		"@":                     "@",
+		"plain-token":           "plain-token",
+	} {
+		value, err := ResolveSecret(ref)
+		s.NoError(err)
+		s.Equal(expected, value)
+	}
+
+	_, err := ResolveSecret("@" + filepath.Join(s.T().TempDir(), "missing"))
+	s.ErrorIs(err, os.ErrNotExist)
+}
+
+// TestResolveAll tests resolving several values
+func (s *SecretRefSuite) TestResolveAll() {
+	s.T().Setenv("WAIT4X_TEST_ADDRESS", "db:5432")
+
+	values, err := ResolveAll([]string{"127.0.0.1:6379", "env:WAIT4X_TEST_ADDRESS"})
+	s.NoError(err)
+	s.Equal([]string{"127.0.0.1:6379", "db:5432"}, values)
+
+	_, err = ResolveAll([]string{"env:WAIT4X_TEST_MISSING"})
+	s.Error(err)
+}
+
+// TestResolveRedacted tests that the resolved values are redacted from the logs and the errors
+func (s *SecretRefSuite) TestResolveRedacted() {
+	s.T().Setenv("WAIT4X_TEST_TOKEN", "Bearer 6fd1a2b3c4")
+
+	_, err := Resolve("env:WAIT4X_TEST_TOKEN")
+	s.Require().NoError(err)
+	s.Equal("token is ***", checker.Redact("token is Bearer 6fd1a2b3c4"))
+}
+
+// TestSecretRefSuite runs the test suite
+func TestSecretRefSuite(t *testing.T) {
+	suite.Run(t, new(SecretRefSuite))
+}
@@ -0,0 +1,209 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targets
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// MaxExpansions is the maximum number of targets a single target can expand to.
+const MaxExpansions = 10000
+
+var (
+	// bracketRangeRegexp matches the content of a numeric range like [1-5].
+	bracketRangeRegexp = regexp.MustCompile(`^(\d+)-(\d+)$`)
+	// braceRangeRegexp matches the content of a numeric range like {1..5}.
+	braceRangeRegexp = regexp.MustCompile(`^(-?\d+)\.\.(-?\d+)$`)
+)
+
+// Expand expands the brace lists and the numeric ranges of the target.
+//
+// The supported forms are brace lists, e.g. "broker-{a,b,c}:9092", and numeric
+// ranges, e.g. "10.0.0.[10-20]:22" or "node{1..5}:2379". Ranges whose bounds have
+// leading zeros are zero-padded, e.g. "node[01-10]". Brackets and braces that
+// don't form one of these, such as IPv6 addresses like "[::1]:53", are kept as is.
+func Expand(target string) ([]string, error) {
+	var results []string
+	if err := expand(target, 0, &results); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ExpandAll expands all the targets, keeping their order.
+func ExpandAll(targets []string) ([]string, error) {
+	var results []string
+	for _, target := range targets {
+		expanded, err := Expand(target)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, expanded...)
+	}
+
+	return results, nil
+}
+
+// expand appends the expansions of s to the results. The part of s before the
+// offset is already expanded.
+func expand(s string, offset int, results *[]string) error {
+	start, end, alternatives, err := findGroup(s, offset)
+	if err != nil {
+		return err
+	}
+
+	if start == -1 {
+		if len(*results) >= MaxExpansions {
+			return fmt.Errorf("the target expands to more than %d targets", MaxExpansions)
+		}
+
+		*results = append(*results, s)
+		return nil
+	}
+
+	for _, alternative := range alternatives {
+		if err := expand(s[:start]+alternative+s[end+1:], start, results); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// findGroup finds the first expandable group of s starting at the offset, and returns
+// its boundaries and alternatives. The start is -1 when there is no expandable group.
+func findGroup(s string, offset int) (start, end int, alternatives []string, err error) {
+	for i := offset; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			j := matchingBrace(s, i)
+			if j == -1 {
+				continue
+			}
+
+			content := s[i+1 : j]
+			if parts := splitTopLevel(content); len(parts) > 1 {
+				return i, j, parts, nil
+			}
+
+			if m := braceRangeRegexp.FindStringSubmatch(content); m != nil {
+				alternatives, err = numericRange(m[1], m[2])
+				return i, j, alternatives, err
+			}
+		case '[':
+			j := strings.IndexByte(s[i:], ']')
+			if j == -1 {
+				continue
+			}
+			j += i
+
+			if m := bracketRangeRegexp.FindStringSubmatch(s[i+1 : j]); m != nil {
+				alternatives, err = numericRange(m[1], m[2])
+				return i, j, alternatives, err
+			}
+		}
+	}
+
+	return -1, -1, nil, nil
+}
+
+// matchingBrace returns the index of the brace closing the one at the given index, or -1.
+func matchingBrace(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// splitTopLevel splits s by the commas that aren't nested in braces.
+func splitTopLevel(s string) []string {
+	var parts []string
+
+	depth, last := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[last:i])
+				last = i + 1
+			}
+		}
+	}
+
+	return append(parts, s[last:])
+}
+
+// numericRange returns the numbers from first to last, inclusive, in either direction.
+func numericRange(first, last string) ([]string, error) {
+	from, err := strconv.Atoi(first)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range bound %q: %w", first, err)
+	}
+
+	to, err := strconv.Atoi(last)
+	if err != nil {
+		return nil, fmt.Errorf("invalid range bound %q: %w", last, err)
+	}
+
+	step := 1
+	if from > to {
+		step = -1
+	}
+
+	if count := (to-from)*step + 1; count > MaxExpansions {
+		return nil, fmt.Errorf("the range %s-%s has more than %d values", first, last, MaxExpansions)
+	}
+
+	// Zero-pad the numbers when a bound has leading zeros, e.g. [01-10].
+	width := 0
+	if isZeroPadded(first) || isZeroPadded(last) {
+		width = max(len(first), len(last))
+	}
+
+	var numbers []string
+	for n := from; ; n += step {
+		numbers = append(numbers, fmt.Sprintf("%0*d", width, n))
+		if n == to {
+			break
+		}
+	}
+
+	return numbers, nil
+}
+
+// isZeroPadded reports whether the number has a leading zero.
+func isZeroPadded(n string) bool {
+	n = strings.TrimPrefix(n, "-")
+	return len(n) > 1 && n[0] == '0'
+}
@@ -0,0 +1,120 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package targets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestExpand tests the brace and range expansion of a target
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected []string
+	}{
+		{
+			name:     "no expansion",
+			target:   "127.0.0.1:9092",
+			expected: []string{"127.0.0.1:9092"},
+		},
+		{
+			name:     "brace list",
+			target:   "kafka://broker-{a,b,c}:9092",
+			expected: []string{"kafka://broker-a:9092", "kafka://broker-b:9092", "kafka://broker-c:9092"},
+		},
+		{
+			name:     "bracket range",
+			target:   "10.0.0.[10-12]:22",
+			expected: []string{"10.0.0.10:22", "10.0.0.11:22", "10.0.0.12:22"},
+		},
+		{
+			name:     "brace range",
+			target:   "node{1..3}:2379",
+			expected: []string{"node1:2379", "node2:2379", "node3:2379"},
+		},
+		{
+			name:     "descending range",
+			target:   "node[3-1]",
+			expected: []string{"node3", "node2", "node1"},
+		},
+		{
+			name:     "zero-padded range",
+			target:   "node[08-10]",
+			expected: []string{"node08", "node09", "node10"},
+		},
+		{
+			name:     "multiple groups",
+			target:   "{db,cache}[1-2]:80",
+			expected: []string{"db1:80", "db2:80", "cache1:80", "cache2:80"},
+		},
+		{
+			name:     "nested brace list",
+			target:   "host-{a,b{1,2}}",
+			expected: []string{"host-a", "host-b1", "host-b2"},
+		},
+		{
+			name:     "ipv6 address",
+			target:   "[::1]:53",
+			expected: []string{"[::1]:53"},
+		},
+		{
+			name:     "ipv6 address with range",
+			target:   "[::1]:[80-81]",
+			expected: []string{"[::1]:80", "[::1]:81"},
+		},
+		{
+			name:     "single brace element",
+			target:   "host-{a}",
+			expected: []string{"host-{a}"},
+		},
+		{
+			name:     "unbalanced brace",
+			target:   "host-{a,b",
+			expected: []string{"host-{a,b"},
+		},
+		{
+			name:     "empty alternative",
+			target:   "host{,-backup}",
+			expected: []string{"host", "host-backup"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, err := Expand(tt.target)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, targets)
+		})
+	}
+}
+
+// TestExpandTooMany tests the expansion limit
+func TestExpandTooMany(t *testing.T) {
+	_, err := Expand("10.0.[0-255].[0-255]")
+	assert.ErrorContains(t, err, "expands to more than")
+
+	_, err = Expand("node[1-100000]")
+	assert.ErrorContains(t, err, "has more than")
+}
+
+// TestExpandAll tests the expansion of several targets
+func TestExpandAll(t *testing.T) {
+	targets, err := ExpandAll([]string{"a[1-2]", "b", "c{x,y}"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a1", "a2", "b", "cx", "cy"}, targets)
+}
@@ -0,0 +1,74 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package targets provides utilities for collecting the targets of the Wait4X commands.
+package targets
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Stdin is the file name that makes ReadFile read from the standard input.
+const Stdin = "-"
+
+// Parse reads newline-separated targets from r.
+// Leading and trailing spaces are trimmed, blank lines and lines starting with "#" are ignored.
+func Parse(r io.Reader) ([]string, error) {
+	var targets []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		targets = append(targets, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return targets, nil
+}
+
+// ReadFile reads the targets from the given file, or from stdin when the path is "-".
+func ReadFile(path string, stdin io.Reader) ([]string, error) {
+	if path == Stdin {
+		targets, err := Parse(stdin)
+		if err != nil {
+			return nil, fmt.Errorf("can't read targets from stdin: %w", err)
+		}
+
+		return targets, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("can't open the targets file: %w", err)
+	}
+	defer f.Close()
+
+	targets, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("can't read the targets file: %w", err)
+	}
+
+	return targets, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package targets provides utilities for collecting the targets of the Wait4X commands.
+package targets
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// TargetsSuite is a test suite for targets package
+type TargetsSuite struct {
+	suite.Suite
+}
+
+// TestParse tests parsing targets with comments and blank lines
+func (s *TargetsSuite) TestParse() {
+	input := `
+# Databases
+127.0.0.1:5432
+
+  127.0.0.1:3306
+	# Caches
+127.0.0.1:6379
+`
+
+	targets, err := Parse(strings.NewReader(input))
+	s.Require().NoError(err)
+	s.Equal([]string{"127.0.0.1:5432", "127.0.0.1:3306", "127.0.0.1:6379"}, targets)
+}
+
+// TestParseEmpty tests parsing an input without any target
+func (s *TargetsSuite) TestParseEmpty() {
+	targets, err := Parse(strings.NewReader("# nothing here\n\n"))
+	s.Require().NoError(err)
+	s.Empty(targets)
+}
+
+// TestReadFile tests reading targets from a file
+func (s *TargetsSuite) TestReadFile() {
+	path := filepath.Join(s.T().TempDir(), "targets")
+	s.Require().NoError(os.WriteFile(path, []byte("redis://127.0.0.1:6379\n# redis://127.0.0.1:6380\n"), 0o600))
+
+	targets, err := ReadFile(path, nil)
+	s.Require().NoError(err)
+	s.Equal([]string{"redis://127.0.0.1:6379"}, targets)
+}
+
+// TestReadFileStdin tests reading targets from stdin
+func (s *TargetsSuite) TestReadFileStdin() {
+	targets, err := ReadFile(Stdin, strings.NewReader("127.0.0.1:80\n127.0.0.1:443\n"))
+	s.Require().NoError(err)
+	s.Equal([]string{"127.0.0.1:80", "127.0.0.1:443"}, targets)
+}
+
+// TestReadFileNotExists tests reading targets from a missing file
+func (s *TargetsSuite) TestReadFileNotExists() {
+	_, err := ReadFile(filepath.Join(s.T().TempDir(), "not-exists"), nil)
+	s.ErrorContains(err, "can't open the targets file")
+}
+
+// TestTargetsSuite runs the test suite
+func TestTargetsSuite(t *testing.T) {
+	suite.Run(t, new(TargetsSuite))
+}
@@ -0,0 +1,64 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// RecordingDialer dials the addresses directly and records them, for the WithDialer tests of the checkers
+type RecordingDialer struct {
+	mu        sync.Mutex
+	addresses []string
+}
+
+// DialContext records the address and connects to it, its method value is a checker dialer
+func (d *RecordingDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	d.mu.Lock()
+	d.addresses = append(d.addresses, address)
+	d.mu.Unlock()
+
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, network, address)
+}
+
+// Addresses returns the addresses dialed so far
+func (d *RecordingDialer) Addresses() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return append([]string(nil), d.addresses...)
+}
+
+// AssertDialerUsed runs the check of a checker that is given the dialer, and asserts that it succeeds
+// over the connections of the dialer
+func AssertDialerUsed(t testing.TB, d *RecordingDialer, check func(ctx context.Context) error) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := check(ctx); err != nil {
+		t.Errorf("the check failed over the dialer: %v", err)
+	}
+
+	if len(d.Addresses()) == 0 {
+		t.Error("the checker didn't connect with the dialer")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// fdDir lists the open file descriptors of the process on Linux and macOS
+const fdDir = "/dev/fd"
+
+// OpenFDs returns the number of the open file descriptors of the process
+func OpenFDs() (int, error) {
+	entries, err := os.ReadDir(fdDir)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(entries), nil
+}
+
+// AssertFDsBounded runs fn the given number of attempts and asserts that the open file descriptors
+// of the process grow by at most slack. The peers get a second to close their side of the connections.
+func AssertFDsBounded(t testing.TB, attempts, slack int, fn func()) {
+	t.Helper()
+
+	before, err := OpenFDs()
+	if err != nil {
+		t.Skipf("can't count the open file descriptors: %v", err)
+	}
+
+	for range attempts {
+		fn()
+	}
+
+	var after int
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		after, err = OpenFDs()
+		if err != nil {
+			t.Fatalf("can't count the open file descriptors: %v", err)
+		}
+
+		if after-before <= slack || time.Now().After(deadline) {
+			break
+		}
+	}
+
+	if after-before > slack {
+		t.Errorf("%d file descriptors are left open after %d attempts, expected at most %d", after-before, attempts, slack)
+	}
+}
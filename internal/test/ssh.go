@@ -0,0 +1,190 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// SSHServer is an in-process SSH server that only serves direct-tcpip channels, for the jump host tests
+type SSHServer struct {
+	// Addr is the address of the server
+	Addr string
+	// ClientKey is the only key accepted by the server
+	ClientKey ssh.Signer
+	// ClientKeyPEM is the OpenSSH PEM encoding of the client key
+	ClientKeyPEM []byte
+	// KnownHosts is the known_hosts line of the server
+	KnownHosts []byte
+
+	listener    net.Listener
+	connections atomic.Int32
+
+	mu    sync.Mutex
+	conns []net.Conn
+}
+
+// NewSSHServer starts a new SSH server on a random local port
+func NewSSHServer() (*SSHServer, error) {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	hostKey, err := ssh.NewSignerFromKey(hostPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	_, clientPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	clientKey, err := ssh.NewSignerFromKey(clientPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+	clientKeyBlock, err := ssh.MarshalPrivateKey(clientPrivateKey, "")
+	if err != nil {
+		return nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SSHServer{
+		Addr:         listener.Addr().String(),
+		ClientKey:    clientKey,
+		ClientKeyPEM: pem.EncodeToMemory(clientKeyBlock),
+		KnownHosts:   []byte(knownhosts.Line([]string{knownhosts.Normalize(listener.Addr().String())}, hostKey.PublicKey()) + "\n"),
+		listener:     listener,
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if !bytes.Equal(key.Marshal(), clientKey.PublicKey().Marshal()) {
+				return nil, ssh.ErrNoAuth
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostKey)
+
+	go s.serve(config)
+
+	return s, nil
+}
+
+// Connections returns the number of the SSH connections the server has accepted
+func (s *SSHServer) Connections() int {
+	return int(s.connections.Load())
+}
+
+// CloseConnections closes the open SSH connections
+func (s *SSHServer) CloseConnections() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.conns = nil
+}
+
+// Close stops the server
+func (s *SSHServer) Close() error {
+	s.CloseConnections()
+	return s.listener.Close()
+}
+
+// serve accepts the connections until the listener is closed
+func (s *SSHServer) serve(config *ssh.ServerConfig) {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return // listener closed
+		}
+
+		s.mu.Lock()
+		s.conns = append(s.conns, conn)
+		s.mu.Unlock()
+
+		go s.handle(conn, config)
+	}
+}
+
+// handle serves the direct-tcpip channels of an SSH connection
+func (s *SSHServer) handle(conn net.Conn, config *ssh.ServerConfig) {
+	defer conn.Close()
+
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	s.connections.Add(1)
+
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "direct-tcpip" {
+			newChannel.Reject(ssh.UnknownChannelType, "only direct-tcpip channels are supported")
+			continue
+		}
+
+		var payload struct {
+			Host       string
+			Port       uint32
+			OriginHost string
+			OriginPort uint32
+		}
+		if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+
+		upstream, err := net.Dial("tcp", net.JoinHostPort(payload.Host, strconv.Itoa(int(payload.Port))))
+		if err != nil {
+			newChannel.Reject(ssh.ConnectionFailed, err.Error())
+			continue
+		}
+
+		channel, channelReqs, err := newChannel.Accept()
+		if err != nil {
+			upstream.Close()
+			continue
+		}
+		go ssh.DiscardRequests(channelReqs)
+
+		go func() {
+			defer channel.Close()
+			defer upstream.Close()
+
+			go io.Copy(upstream, channel)
+			io.Copy(channel, upstream)
+		}()
+	}
+}
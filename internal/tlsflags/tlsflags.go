@@ -0,0 +1,127 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tlsflags provides the TLS command-line flags shared by the network commands of the Wait4X application.
+package tlsflags
+
+import (
+	"crypto/tls"
+	"fmt"
+	"slices"
+
+	"github.com/spf13/pflag"
+
+	"wait4x.dev/v3/checker/tlsconfig"
+)
+
+// names are the names of the TLS flags
+var names = []string{
+	"tls-ca-file",
+	"tls-cert-file",
+	"tls-key-file",
+	"tls-server-name",
+	"tls-min-version",
+	"tls-max-version",
+	"tls-cipher-suites",
+	"tls-pin-sha256",
+	"insecure-skip-tls-verify",
+}
+
+// Add adds the TLS flags to the flag set.
+func Add(flags *pflag.FlagSet) {
+	flags.String("tls-ca-file", "", "Use this CA bundle to verify the server certificate, instead of the system ones.")
+	flags.String("tls-cert-file", "", "Use this certificate file to identify the client.")
+	flags.String("tls-key-file", "", "Use this key file to identify the client.")
+	flags.String("tls-server-name", "", "Override the server name for SNI and the certificate verification.")
+	flags.String("tls-min-version", "", "Minimum TLS version (1.0, 1.1, 1.2 or 1.3).")
+	flags.String("tls-max-version", "", "Maximum TLS version (1.0, 1.1, 1.2 or 1.3).")
+	flags.StringSlice("tls-cipher-suites", nil, "Comma-separated TLS 1.0-1.2 cipher suites, e.g. TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256.")
+	flags.StringArray("tls-pin-sha256", nil, "Pin the base64 SHA-256 hash of a public key in the server certificate chain, e.g. sha256/AAAA...=.")
+	flags.Bool("insecure-skip-tls-verify", false, "Skips tls certificate checks.")
+}
+
+// Config returns the TLS config of the flags, or nil when none of them has been set.
+func Config(flags *pflag.FlagSet) (*tls.Config, error) {
+	if !slices.ContainsFunc(names, flags.Changed) {
+		return nil, nil
+	}
+
+	var opts []tlsconfig.Option
+
+	for _, flag := range []struct {
+		name   string
+		option func(string) tlsconfig.Option
+	}{
+		{"tls-ca-file", tlsconfig.WithCAFile},
+		{"tls-cert-file", tlsconfig.WithCertFile},
+		{"tls-key-file", tlsconfig.WithKeyFile},
+		{"tls-server-name", tlsconfig.WithServerName},
+	} {
+		value, err := flags.GetString(flag.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --%s flag: %w", flag.name, err)
+		}
+		opts = append(opts, flag.option(value))
+	}
+
+	for _, flag := range []struct {
+		name   string
+		option func(uint16) tlsconfig.Option
+	}{
+		{"tls-min-version", tlsconfig.WithMinVersion},
+		{"tls-max-version", tlsconfig.WithMaxVersion},
+	} {
+		value, err := flags.GetString(flag.name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --%s flag: %w", flag.name, err)
+		}
+
+		if value == "" {
+			continue
+		}
+
+		version, err := tlsconfig.ParseVersion(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --%s flag: %w", flag.name, err)
+		}
+		opts = append(opts, flag.option(version))
+	}
+
+	cipherSuiteNames, err := flags.GetStringSlice("tls-cipher-suites")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --tls-cipher-suites flag: %w", err)
+	}
+
+	if len(cipherSuiteNames) > 0 {
+		cipherSuites, err := tlsconfig.ParseCipherSuites(cipherSuiteNames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse --tls-cipher-suites flag: %w", err)
+		}
+		opts = append(opts, tlsconfig.WithCipherSuites(cipherSuites))
+	}
+
+	pins, err := flags.GetStringArray("tls-pin-sha256")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --tls-pin-sha256 flag: %w", err)
+	}
+	opts = append(opts, tlsconfig.WithPinnedSPKI(pins))
+
+	insecureSkipVerify, err := flags.GetBool("insecure-skip-tls-verify")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse --insecure-skip-tls-verify flag: %w", err)
+	}
+	opts = append(opts, tlsconfig.WithInsecureSkipVerify(insecureSkipVerify))
+
+	return tlsconfig.New(opts...)
+}
@@ -0,0 +1,80 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tlsflags
+
+import (
+	"crypto/tls"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/suite"
+)
+
+// TLSFlagsSuite is a test suite for tlsflags package
+type TLSFlagsSuite struct {
+	suite.Suite
+}
+
+// parse adds the TLS flags to a new flag set and parses the arguments
+func (s *TLSFlagsSuite) parse(args ...string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	Add(flags)
+	s.Require().NoError(flags.Parse(args))
+
+	return flags
+}
+
+// TestConfigWithoutFlags tests that no TLS config is built without the flags
+func (s *TLSFlagsSuite) TestConfigWithoutFlags() {
+	cfg, err := Config(s.parse())
+	s.NoError(err)
+	s.Nil(cfg)
+}
+
+// TestConfig tests building the TLS config from the flags
+func (s *TLSFlagsSuite) TestConfig() {
+	cfg, err := Config(s.parse(
+		"--tls-server-name", "example.com",
+		"--tls-min-version", "1.2",
+		"--tls-max-version", "1.3",
+		"--tls-cipher-suites", "TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+		"--tls-pin-sha256", "sha256/47DEQpj8HBSa+/TImW+5JCeuQeRkm5NMpJWZG3hSuFU=",
+		"--insecure-skip-tls-verify",
+	))
+	s.Require().NoError(err)
+	s.Equal("example.com", cfg.ServerName)
+	s.Equal(uint16(tls.VersionTLS12), cfg.MinVersion)
+	s.Equal(uint16(tls.VersionTLS13), cfg.MaxVersion)
+	s.Equal([]uint16{tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384}, cfg.CipherSuites)
+	s.NotNil(cfg.VerifyConnection)
+	s.True(cfg.InsecureSkipVerify)
+}
+
+// TestConfigInvalidFlags tests the errors of invalid flags
+func (s *TLSFlagsSuite) TestConfigInvalidFlags() {
+	_, err := Config(s.parse("--tls-min-version", "1.4"))
+	s.ErrorContains(err, "failed to parse --tls-min-version flag")
+
+	_, err = Config(s.parse("--tls-cipher-suites", "TLS_UNKNOWN"))
+	s.ErrorContains(err, "failed to parse --tls-cipher-suites flag")
+
+	_, err = Config(s.parse("--tls-cert-file", "cert.pem"))
+	s.ErrorContains(err, "both cert file and key file")
+}
+
+// TestTLSFlagsSuite runs the test suite
+func TestTLSFlagsSuite(t *testing.T) {
+	suite.Run(t, new(TLSFlagsSuite))
+}
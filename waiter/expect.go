@@ -0,0 +1,101 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+	"wait4x.dev/v3/checker"
+)
+
+// ErrNoObservations is returned when an expect expression is set on a checker that makes no observations
+var ErrNoObservations = errors.New("the checker makes no observations for the expect expression")
+
+// expectation is an expression that the observations of a successful check must satisfy
+type expectation struct {
+	expression string
+	program    *vm.Program
+	names      []string
+}
+
+// compileExpectation compiles the expression of an expectation
+func compileExpectation(expression string) (*expectation, error) {
+	program, err := expr.Compile(expression, expr.AsBool(), expr.AllowUndefinedVariables())
+	if err != nil {
+		return nil, fmt.Errorf("invalid expect expression: %w", err)
+	}
+
+	collector := new(identifierCollector)
+	node := program.Node()
+	ast.Walk(&node, collector)
+
+	return &expectation{
+		expression: expression,
+		program:    program,
+		names:      collector.names,
+	}, nil
+}
+
+// supports checks that the checker makes the observations that the expression is evaluated against,
+// otherwise the expression never matches and the wait would only time out
+func (e *expectation) supports(chk checker.Checker, desc checker.Description) error {
+	observer, ok := chk.(checker.Observer)
+	if !ok || len(observer.ObservationNames()) == 0 {
+		return fmt.Errorf("%w: the %s checker", ErrNoObservations, desc.Kind)
+	}
+
+	return nil
+}
+
+// requestObservations requests the observations of the expression from the checks made with the context
+func (e *expectation) requestObservations(ctx context.Context) context.Context {
+	return checker.RequestObservations(ctx, e.names...)
+}
+
+// check checks that the observations satisfy the expression, the last observation of a name is its value
+func (e *expectation) check(observations checker.Observations) error {
+	env := make(map[string]any, len(observations))
+	for _, observation := range observations {
+		env[observation.Name] = observation.Value
+	}
+
+	result, err := expr.Run(e.program, env)
+	if err != nil {
+		return checker.NewExpectedError("failed to evaluate the expect expression", err, "expect", e.expression)
+	}
+
+	if matched, _ := result.(bool); !matched {
+		return checker.NewExpectedError("the observations don't match the expect expression", nil, "expect", e.expression)
+	}
+
+	return nil
+}
+
+// identifierCollector collects the names of the identifiers of an expression
+type identifierCollector struct {
+	names []string
+}
+
+// Visit collects the name of an identifier node
+func (c *identifierCollector) Visit(node *ast.Node) {
+	if identifier, ok := (*node).(*ast.IdentifierNode); ok {
+		c.names = append(c.names, identifier.Value)
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright 2019-2025 The Wait4X Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package waiter
+
+import (
+	"github.com/go-logr/logr"
+	"wait4x.dev/v3/checker"
+)
+
+// redactSink is a logr.LogSink that redacts the secrets of the messages, errors and values
+type redactSink struct {
+	logr.LogSink
+}
+
+// redactLogger returns the logger with its secrets redacted
+func redactLogger(logger logr.Logger) logr.Logger {
+	sink := logger.GetSink()
+	if sink == nil {
+		return logger
+	}
+
+	// The sink is called from one more frame.
+	if cd, ok := sink.(logr.CallDepthLogSink); ok {
+		sink = cd.WithCallDepth(1)
+	}
+
+	return logger.WithSink(redactSink{sink})
+}
+
+func (s redactSink) Info(level int, msg string, keysAndValues ...any) {
+	s.LogSink.Info(level, checker.Redact(msg), checker.RedactDetails(keysAndValues)...)
+}
+
+func (s redactSink) Error(err error, msg string, keysAndValues ...any) {
+	s.LogSink.Error(checker.RedactError(err), checker.Redact(msg), checker.RedactDetails(keysAndValues)...)
+}
+
+func (s redactSink) WithValues(keysAndValues ...any) logr.LogSink {
+	return redactSink{s.LogSink.WithValues(checker.RedactDetails(keysAndValues)...)}
+}
+
+func (s redactSink) WithName(name string) logr.LogSink {
+	return redactSink{s.LogSink.WithName(name)}
+}
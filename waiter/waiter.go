@@ -19,7 +19,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"reflect"
 	"sync"
 	"time"
 
@@ -50,6 +49,8 @@ type options struct {
 	backoffPolicy                 string
 	backoffExponentialMaxInterval time.Duration
 	backoffCoefficient            float64
+	observationHandler            func(checker.Description, checker.Observations)
+	expect                        string
 }
 
 // WithTimeout configures a time limit for whole of checking
@@ -102,6 +103,22 @@ func WithBackoffCoefficient(backoffCoefficient float64) Option {
 	}
 }
 
+// WithObservationHandler configures a handler of the observations of the check that ends up the wait,
+// with the description of its checker.
+func WithObservationHandler(handler func(checker.Description, checker.Observations)) Option {
+	return func(o *options) {
+		o.observationHandler = handler
+	}
+}
+
+// WithExpect configures an expression that the observations of a successful check must satisfy,
+// e.g. `status_code == 200 && json.version >= "2.3"`. See https://expr-lang.org for its syntax.
+func WithExpect(expression string) Option {
+	return func(o *options) {
+		o.expect = expression
+	}
+}
+
 // WaitParallel waits for end up all of checks execution.
 func WaitParallel(checkers []checker.Checker, opts ...Option) error {
 	return WaitParallelContext(context.Background(), checkers, opts...)
@@ -172,6 +189,9 @@ func WaitContext(ctx context.Context, chk checker.Checker, opts ...Option) error
 		opt(options)
 	}
 
+	// The identities, errors and details of the checkers may have secrets, e.g. the DSNs
+	options.logger = redactLogger(options.logger)
+
 	// Validate backoff policy once outside the loop
 	if options.backoffPolicy != BackoffPolicyLinear && options.backoffPolicy != BackoffPolicyExponential {
 		return fmt.Errorf("invalid backoff policy: %s", options.backoffPolicy)
@@ -192,6 +212,15 @@ func WaitContext(ctx context.Context, chk checker.Checker, opts ...Option) error
 		return fmt.Errorf("interval must be positive, got: %v", options.interval)
 	}
 
+	var expect *expectation
+	if options.expect != "" {
+		var err error
+		expect, err = compileExpectation(options.expect)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Ignore timeout context when the timeout is unlimited
 	if options.timeout != 0 {
 		var cancel func()
@@ -199,16 +228,24 @@ func WaitContext(ctx context.Context, chk checker.Checker, opts ...Option) error
 		defer cancel()
 	}
 
-	var chkName string
-	if t := reflect.TypeOf(chk); t.Kind() == reflect.Pointer {
-		chkName = t.Elem().Name()
-	} else {
-		chkName = t.Name()
+	desc, err := checker.Describe(chk)
+	if err != nil {
+		return checker.RedactError(err)
 	}
 
-	chkID, err := chk.Identity()
-	if err != nil {
-		return err
+	if expect != nil {
+		if err := expect.supports(chk, desc); err != nil {
+			return err
+		}
+	}
+
+	// Release the resources that the checker keeps between the checks, e.g. in the persistent mode
+	if closer, ok := chk.(checker.Closer); ok {
+		defer func() {
+			if err := closer.Close(); err != nil {
+				options.logger.Error(err, "Failed to close the checker", "checker", desc.Kind, "address", desc.Display)
+			}
+		}()
 	}
 
 	// This is a counter for exponential backoff
@@ -217,18 +254,29 @@ func WaitContext(ctx context.Context, chk checker.Checker, opts ...Option) error
 	retries := 0
 
 	for {
-		options.logger.Info(fmt.Sprintf("[%s] Checking %s ...", chkName, chkID))
+		options.logger.Info(fmt.Sprintf("[%s] Checking %s ...", desc.Kind, desc.Display))
+
+		checkCtx, observations := checker.WithObservations(ctx)
+		if expect != nil {
+			checkCtx = expect.requestObservations(checkCtx)
+		}
 
-		err := chk.Check(ctx)
+		err := chk.Check(checkCtx)
+		observed := observations()
+		if err == nil && expect != nil {
+			err = expect.check(observed)
+		}
 		if err != nil {
 			var expectedError *checker.ExpectedError
 			if errors.As(err, &expectedError) {
-				options.logger.Error(expectedError, "Expectation failed", expectedError.Details()...)
+				options.logger.Error(expectedError, "Expectation failed", append(expectedError.Details(), observed.KeysAndValues()...)...)
 			} else {
 				if !errors.Is(err, context.DeadlineExceeded) {
-					options.logger.Error(err, "Error occurred")
+					options.logger.Error(err, "Error occurred", observed.KeysAndValues()...)
 				}
 			}
+		} else if len(observed) > 0 {
+			options.logger.Info(fmt.Sprintf("[%s] Check succeeded", desc.Kind), observed.KeysAndValues()...)
 		}
 
 		// Check if we should stop based on the check result
@@ -236,6 +284,9 @@ func WaitContext(ctx context.Context, chk checker.Checker, opts ...Option) error
 		// For inverted checks: stop when err is not nil (failure is success)
 		shouldStop := (err == nil && !options.invertCheck) || (err != nil && options.invertCheck)
 		if shouldStop {
+			if options.observationHandler != nil {
+				options.observationHandler(desc, observed)
+			}
 			break
 		}
 
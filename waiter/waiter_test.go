@@ -89,6 +89,140 @@ func TestWaitLogger(t *testing.T) {
 	mockChecker.AssertExpectations(t)
 }
 
+// TestWaitLoggerRedacted tests that the Waiter redacts the secrets of the logs.
+func TestWaitLoggerRedacted(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Check", mock.Anything).
+		Return(checker.NewExpectedError("failed", nil, "dsn", "bob:secret@tcp(db:3306)/app")).Once().
+		On("Check", mock.Anything).
+		Return(fmt.Errorf("can't connect to postgresql://bob:secret@db/app")).
+		On("Identity").Return("redis://:secret@cache:6379", nil)
+
+	var buf bytes.Buffer
+	var log = buflogr.NewWithBuffer(&buf)
+	err := WaitContext(context.TODO(), mockChecker, WithLogger(log), WithTimeout(time.Second), WithInterval(100*time.Millisecond))
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Contains(t, buf.String(), "Checking redis://:***@cache:6379 ...")
+	assert.Contains(t, buf.String(), "bob:***@tcp(db:3306)/app")
+	assert.Contains(t, buf.String(), "can't connect to postgresql://bob:***@db/app")
+	assert.NotContains(t, buf.String(), "secret")
+	mockChecker.AssertExpectations(t)
+}
+
+// describerMockChecker is a MockChecker that describes itself.
+type describerMockChecker struct {
+	checker.MockChecker
+}
+
+// Describe returns the description of the checker
+func (mc *describerMockChecker) Describe() (checker.Description, error) {
+	return checker.Description{Kind: "Custom", Display: "custom://target"}, nil
+}
+
+// TestWaitLoggerDescriber tests that the Waiter logs the description of a Describer.
+func TestWaitLoggerDescriber(t *testing.T) {
+	mockChecker := new(describerMockChecker)
+	mockChecker.On("Check", mock.Anything).Return(nil)
+
+	var buf bytes.Buffer
+	err := WaitContext(context.TODO(), mockChecker, WithLogger(buflogr.NewWithBuffer(&buf)))
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "INFO [Custom] Checking custom://target ...")
+	mockChecker.AssertExpectations(t)
+}
+
+// TestWaitObservations tests that the Waiter logs and hands over the observations of the checks.
+func TestWaitObservations(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Identity").Return("ID", nil)
+	mockChecker.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		checker.Observe(args.Get(0).(context.Context), "status_code", 503)
+	}).Return(fmt.Errorf("error message")).Once()
+	mockChecker.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+		checker.Observe(args.Get(0).(context.Context), "status_code", 200)
+	}).Return(nil).Once()
+
+	var buf bytes.Buffer
+	var handled checker.Observations
+	err := WaitContext(
+		context.TODO(), mockChecker,
+		WithLogger(buflogr.NewWithBuffer(&buf)),
+		WithInterval(10*time.Millisecond),
+		WithObservationHandler(func(desc checker.Description, observations checker.Observations) {
+			assert.Equal(t, "MockChecker", desc.Kind)
+			handled = observations
+		}),
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, checker.Observations{{Name: "status_code", Value: 200}}, handled)
+	assert.Contains(t, buf.String(), "status_code 503")
+	assert.Contains(t, buf.String(), "INFO [MockChecker] Check succeeded status_code 200")
+	mockChecker.AssertExpectations(t)
+}
+
+// observerMockChecker is a MockChecker that makes observations.
+type observerMockChecker struct {
+	checker.MockChecker
+}
+
+// ObservationNames returns the names of the observations of the checker
+func (mc *observerMockChecker) ObservationNames() []string {
+	return []string{"brokers"}
+}
+
+// TestWaitExpect tests that the Waiter waits for the observations to match the expect expression.
+func TestWaitExpect(t *testing.T) {
+	mockChecker := new(observerMockChecker)
+	mockChecker.On("Identity").Return("ID", nil)
+	for _, brokers := range [][]string{{"b1:9092"}, {"b1:9092", "b2:9092", "b3:9092"}} {
+		mockChecker.On("Check", mock.Anything).Run(func(args mock.Arguments) {
+			ctx := args.Get(0).(context.Context)
+			assert.True(t, checker.ObservationRequested(ctx, "brokers"))
+			checker.Observe(ctx, "brokers", brokers)
+		}).Return(nil).Once()
+	}
+
+	var buf bytes.Buffer
+	err := WaitContext(
+		context.TODO(), mockChecker,
+		WithLogger(buflogr.NewWithBuffer(&buf)),
+		WithInterval(10*time.Millisecond),
+		WithExpect("len(brokers) >= 3"),
+	)
+
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "the observations don't match the expect expression")
+	mockChecker.AssertExpectations(t)
+}
+
+// TestWaitExpectWithoutObservations tests that the Waiter fails fast with an expect expression on a checker
+// that makes no observations.
+func TestWaitExpectWithoutObservations(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+	mockChecker.On("Identity").Return("ID", nil)
+
+	start := time.Now()
+	err := Wait(mockChecker, WithTimeout(5*time.Second), WithExpect("len(brokers) >= 3"))
+
+	assert.ErrorIs(t, err, ErrNoObservations)
+	assert.ErrorContains(t, err, "MockChecker")
+	assert.Less(t, time.Since(start), time.Second)
+	mockChecker.AssertNotCalled(t, "Check", mock.Anything)
+}
+
+// TestWaitInvalidExpect tests the Waiter with an invalid expect expression.
+func TestWaitInvalidExpect(t *testing.T) {
+	mockChecker := new(checker.MockChecker)
+
+	err := Wait(mockChecker, WithExpect("len(brokers) >="))
+
+	assert.ErrorContains(t, err, "invalid expect expression")
+	mockChecker.AssertNotCalled(t, "Check", mock.Anything)
+}
+
 // TestWaitInvertCheck tests the Waiter with an inverted check.
 func TestWaitInvertCheck(t *testing.T) {
 	alwaysTrue := new(checker.MockChecker)
@@ -416,3 +550,39 @@ func TestWaitExponentialBackoffWithLargeCoefficient(t *testing.T) {
 	assert.Less(t, elapsed, 2*time.Second)
 	mockChecker.AssertExpectations(t)
 }
+
+// closerMockChecker is a MockChecker that mocks the io.Closer too.
+type closerMockChecker struct {
+	checker.MockChecker
+}
+
+// Close mocks the checker's close
+func (mc *closerMockChecker) Close() error {
+	args := mc.Called()
+
+	return args.Error(0)
+}
+
+// TestWaitClose tests that the Waiter closes the checker when the wait finishes.
+func TestWaitClose(t *testing.T) {
+	mockChecker := new(closerMockChecker)
+	mockChecker.On("Identity").Return("ID", nil)
+	mockChecker.On("Check", mock.Anything).Return(fmt.Errorf("error")).Once()
+	mockChecker.On("Check", mock.Anything).Return(nil).Once()
+	mockChecker.On("Close").Return(nil).Once()
+
+	err := Wait(mockChecker, WithInterval(10*time.Millisecond))
+
+	assert.Nil(t, err)
+	mockChecker.AssertExpectations(t)
+
+	mockChecker = new(closerMockChecker)
+	mockChecker.On("Identity").Return("ID", nil)
+	mockChecker.On("Check", mock.Anything).Return(fmt.Errorf("error"))
+	mockChecker.On("Close").Return(nil).Once()
+
+	err = Wait(mockChecker, WithInterval(10*time.Millisecond), WithTimeout(100*time.Millisecond))
+
+	assert.Equal(t, context.DeadlineExceeded, err)
+	mockChecker.AssertExpectations(t)
+}